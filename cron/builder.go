@@ -0,0 +1,202 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Spec is a single value, a range or a step of a Builder field, e.g. Minutes(Value(0), Value(30)),
+// Hours(Range(9, 17)), Minutes(Every(15)), Hours(Range(9, 17).Every(2)) or DaysOfWeek(Range(time.Monday, time.Friday)).
+// The zero Spec is the value 0.
+type Spec struct {
+	kind     specKind
+	from, to int
+	step     int
+}
+
+type specKind int
+
+const (
+	valueSpec specKind = iota // a
+	rangeSpec                 // a-b, a-b/n
+	fromSpec                  // a/n, from a to the end of the field range
+	allSpec                   // */n
+)
+
+// Value returns the single value v, like 5, time.March or time.Friday.
+func Value[T ~int](v T) Spec {
+	return Spec{kind: valueSpec, from: int(v), to: int(v), step: 1}
+}
+
+// Range returns the values from a through b, both inclusive.
+func Range[T ~int](a, b T) Spec {
+	return Spec{kind: rangeSpec, from: int(a), to: int(b), step: 1}
+}
+
+// Every returns every n-th value of the field range starting at its first value, like */n.
+func Every(n int) Spec {
+	return Spec{kind: allSpec, step: n}
+}
+
+// Every returns every n-th value of the range, or of the values from a single value to the end of the field range
+// like a/n.
+func (s Spec) Every(n int) Spec {
+	if s.kind == valueSpec {
+		s.kind = fromSpec
+	}
+	s.step = n
+	return s
+}
+
+// String returns the spec like a token of a field.
+func (s Spec) String() string {
+	switch s.kind {
+	case rangeSpec:
+		if s.step == 1 {
+			return fmt.Sprintf("%d-%d", s.from, s.to)
+		}
+		return fmt.Sprintf("%d-%d/%d", s.from, s.to, s.step)
+	case fromSpec:
+		return fmt.Sprintf("%d/%d", s.from, s.step)
+	case allSpec:
+		return fmt.Sprintf("*/%d", s.step)
+	}
+	return strconv.Itoa(s.from)
+}
+
+// Builder assembles a CronExpression field by field. Fields that aren't set match every value, except the seconds
+// and milliseconds, which default to 0 like in the five-field Unix format.
+type Builder struct {
+	specs map[CronFieldType][]Spec
+	opts  []Option
+}
+
+// NewBuilder returns a builder of an expression firing every minute.
+func NewBuilder() *Builder {
+	return &Builder{specs: map[CronFieldType][]Spec{}}
+}
+
+// Milliseconds adds a millisecond field, see WithMilliseconds.
+func (b *Builder) Milliseconds(specs ...Spec) *Builder {
+	return b.set(Millisecond, specs)
+}
+
+func (b *Builder) Seconds(specs ...Spec) *Builder {
+	return b.set(Second, specs)
+}
+
+func (b *Builder) Minutes(specs ...Spec) *Builder {
+	return b.set(Minute, specs)
+}
+
+func (b *Builder) Hours(specs ...Spec) *Builder {
+	return b.set(Hour, specs)
+}
+
+func (b *Builder) DaysOfMonth(specs ...Spec) *Builder {
+	return b.set(DOM, specs)
+}
+
+// Months sets the month field, e.g. Months(Value(time.March)) or Months(Every(3)).
+func (b *Builder) Months(specs ...Spec) *Builder {
+	return b.set(Month, specs)
+}
+
+// DaysOfWeek sets the day of week field, e.g. DaysOfWeek(Range(time.Monday, time.Friday)), 0 and 7 are both Sunday.
+func (b *Builder) DaysOfWeek(specs ...Spec) *Builder {
+	return b.set(DOW, specs)
+}
+
+func (b *Builder) Years(specs ...Spec) *Builder {
+	return b.set(Year, specs)
+}
+
+// Options sets the options the expression is built with, like the options of Parse.
+func (b *Builder) Options(opts ...Option) *Builder {
+	b.opts = append(b.opts, opts...)
+	return b
+}
+
+func (b *Builder) set(fieldType CronFieldType, specs []Spec) *Builder {
+	b.specs[fieldType] = append(b.specs[fieldType], specs...)
+	return b
+}
+
+// Build validates the fields against their ranges and returns the expression, its String is the canonical form.
+func (b *Builder) Build() (*CronExpression, error) {
+	o := options{}
+	for _, opt := range b.opts {
+		opt(&o)
+	}
+	if o.jitter > 0 {
+		o.rand()
+	}
+	var fields []CronField
+	for _, fieldType := range []CronFieldType{Year, DOW, Month, DOM, Hour, Minute, Second, Millisecond} {
+		specs, ok := b.specs[fieldType]
+		if !ok && (fieldType == Year || fieldType == Millisecond) {
+			continue
+		}
+		field, err := buildField(fieldType, specs)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, *field)
+	}
+	if _, ok := b.specs[Millisecond]; ok {
+		o.milliseconds = true
+	}
+	cr := &CronExpression{fields: fields, options: o}
+	if o.dayPolicy == DayQuartz && !cr.field(DOM).isFull() && !cr.field(DOW).isFull() {
+		return nil, &ParseError{Reason: ReasonDayConflict}
+	}
+	if cr.neverFires() {
+		return nil, &ParseError{Reason: ReasonNeverFires}
+	}
+	cr.expression = cr.String()
+	return cr, nil
+}
+
+func buildField(fieldType CronFieldType, specs []Spec) (*CronField, error) {
+	fr := fieldRange[fieldType]
+	field := CronField{fieldType: fieldType, fieldRange: fr}
+	if len(specs) == 0 {
+		if fieldType == Second || fieldType == Millisecond {
+			field.setBit(0)
+		} else {
+			field.setBits(&fr)
+		}
+		return &field, nil
+	}
+	for _, spec := range specs {
+		a, b, step := spec.from, spec.to, spec.step
+		switch spec.kind {
+		case valueSpec:
+			step = 1
+		case fromSpec:
+			b = fr.max
+		case allSpec:
+			a, b = fr.min, fr.max
+		}
+		if step < 1 {
+			return nil, &ParseError{Field: fieldType, Token: spec.String(), Reason: ReasonInvalidStep}
+		}
+		for _, value := range []int{a, b} {
+			if !fr.IsValid(value) {
+				return nil, &ParseError{Field: fieldType, Token: strconv.Itoa(value), Reason: ReasonOutOfRange}
+			}
+		}
+		if a > b {
+			return nil, &ParseError{Field: fieldType, Token: spec.String(), Reason: ReasonInvalidRange}
+		}
+		for v := a; v <= b; v += step {
+			field.setBit(v)
+		}
+	}
+	if fieldType == DOW && field.GetBit(7) == 1 {
+		// 0 and 7 are both Sunday
+		field.bits &^= 1 << 7
+		field.setBit(0)
+	}
+	return &field, nil
+}
@@ -0,0 +1,83 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	tests := []struct {
+		builder  *Builder
+		expected string
+	}{
+		{NewBuilder(), "0 * * * * *"},
+		{NewBuilder().Seconds(Value(0)).Minutes(Every(15)).Hours(Range(9, 17)).DaysOfWeek(Value(time.Monday), Value(time.Friday)), "0 */15 9-17 * * MON,FRI"},
+		{NewBuilder().Minutes(Value(0), Value(30)).Hours(Range(9, 17).Every(2)), "0 0,30 9-17/2 * * *"},
+		{NewBuilder().Minutes(Value(5).Every(20)), "0 5/20 * * * *"},
+		{NewBuilder().Seconds(Range(0, 59)).Minutes(Value(1), Value(2), Value(3), Value(10)), "* 1-3,10 * * * *"},
+		{NewBuilder().Minutes(Value(0)).Hours(Value(12)).DaysOfMonth(Value(1), Value(15)).Months(Value(time.January), Value(time.March), Value(time.July)), "0 0 12 1,15 JAN,MAR,JUL *"},
+		{NewBuilder().Minutes(Value(0)).Hours(Value(0)).DaysOfWeek(Value(time.Sunday), Value(time.Wednesday)).Years(Range(2030, 2040).Every(5)), "0 0 0 * * SUN,WED 2030-2040/5"},
+		{NewBuilder().Milliseconds(Value(250), Value(750)).Seconds(Every(1)), "250,750 * * * * * *"},
+		{NewBuilder().Minutes(Value(0)).Hours(Value(9)).Options(WithLocation(time.UTC)), "CRON_TZ=UTC 0 0 9 * * *"},
+		{NewBuilder().Minutes(Value(0)).Hours(Value(9)).DaysOfWeek(Range(time.Monday, time.Friday)), "0 0 9 * * MON-FRI"},
+		{NewBuilder().Minutes(Value(0)).Hours(Value(0)).DaysOfMonth(Value(1)).Months(Every(3)), "0 0 0 1 */3 *"},
+		{NewBuilder().Minutes(Spec{}).Hours(Value(0)).Months(Value(time.March).Every(4)), "0 0 0 * MAR/4 *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			cr, err := tt.builder.Build()
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := cr.String(); actual != tt.expected {
+				t.Errorf("Expected: %q Actual: %q", tt.expected, actual)
+			}
+			parsed, err := Parse(tt.expected, WithMilliseconds())
+			if err != nil {
+				parsed, err = Parse(tt.expected)
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			from := parseTime("2012-07-09 14:46")
+			if expected, actual := parsed.Next(from), cr.Next(from); actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+
+	errorTests := []struct {
+		builder *Builder
+		field   CronFieldType
+		token   string
+		reason  Reason
+	}{
+		{NewBuilder().Seconds(Value(60)), Second, "60", ReasonOutOfRange},
+		{NewBuilder().Minutes(Value(-1)), Minute, "-1", ReasonOutOfRange},
+		{NewBuilder().Hours(Range(17, 9)), Hour, "17-9", ReasonInvalidRange},
+		{NewBuilder().Hours(Range(9, 24)), Hour, "24", ReasonOutOfRange},
+		{NewBuilder().Hours(Value(1 << 48)), Hour, "281474976710656", ReasonOutOfRange},
+		{NewBuilder().Hours(Range(1, 5).Every(0)), Hour, "1-5/0", ReasonInvalidStep},
+		{NewBuilder().DaysOfWeek(Range(time.Friday, time.Monday)), DOW, "5-1", ReasonInvalidRange},
+		{NewBuilder().Minutes(Every(0)), Minute, "*/0", ReasonInvalidStep},
+		{NewBuilder().Minutes(Every(-5)), Minute, "*/-5", ReasonInvalidStep},
+		{NewBuilder().Months(Value(13)), Month, "13", ReasonOutOfRange},
+		{NewBuilder().Years(Value(1969)), Year, "1969", ReasonOutOfRange},
+		{NewBuilder().DaysOfMonth(Value(30)).Months(Value(time.February)), 0, "", ReasonNeverFires},
+		{NewBuilder().DaysOfMonth(Value(1)).DaysOfWeek(Value(time.Monday)).Options(WithDayPolicy(DayQuartz)), 0, "", ReasonDayConflict},
+	}
+	for i, tt := range errorTests {
+		t.Run(fmt.Sprintf("error #%d", i), func(t *testing.T) {
+			_, err := tt.builder.Build()
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected ParseError, actual: %v", err)
+			}
+			if parseErr.Field != tt.field || parseErr.Token != tt.token || parseErr.Reason != tt.reason {
+				t.Errorf("Unexpected error: %#v", parseErr)
+			}
+		})
+	}
+}
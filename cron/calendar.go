@@ -0,0 +1,139 @@
+package cron
+
+import "time"
+
+// Calendar is a set of excluded instants, e.g. bank holidays and maintenance windows. Attached to an expression with
+// WithCalendar, Next and Prev skip the excluded instants. Dates and weekly windows are wall clock times evaluated in
+// the location of the expression.
+//
+// The zero value excludes nothing, a Calendar must not be modified while expressions using it are evaluated.
+type Calendar struct {
+	days    []dayRange
+	periods []period
+	windows []window
+}
+
+// dayRange is an inclusive range of dates, encoded as yyyymmdd.
+type dayRange struct {
+	from, to int
+}
+
+// period is a half-open range of instants.
+type period struct {
+	start, end time.Time
+}
+
+// window is a half-open range of wall clock times on a day of week, as offsets from midnight.
+type window struct {
+	weekday  time.Weekday
+	from, to time.Duration
+}
+
+// AddDate excludes the whole days of the given dates, only the year, month and day of the dates are used.
+func (c *Calendar) AddDate(dates ...time.Time) *Calendar {
+	for _, date := range dates {
+		c.days = append(c.days, dayRange{from: dateKey(date), to: dateKey(date)})
+	}
+	return c
+}
+
+// AddDateRange excludes the whole days from one date through another, both inclusive.
+func (c *Calendar) AddDateRange(from, to time.Time) *Calendar {
+	c.days = append(c.days, dayRange{from: dateKey(from), to: dateKey(to)})
+	return c
+}
+
+// AddPeriod excludes the instants from start up to, but not including, end.
+func (c *Calendar) AddPeriod(start, end time.Time) *Calendar {
+	c.periods = append(c.periods, period{start: start, end: end})
+	return c
+}
+
+// AddWeeklyWindow excludes the wall clock times from one offset from midnight up to, but not including, another on
+// every given day of week, e.g. AddWeeklyWindow(time.Sunday, 2*time.Hour, 4*time.Hour). The window may reach into the
+// next day, i.e. to may be up to 48 hours.
+func (c *Calendar) AddWeeklyWindow(weekday time.Weekday, from, to time.Duration) *Calendar {
+	c.windows = append(c.windows, window{weekday: weekday, from: from, to: to})
+	return c
+}
+
+// Excludes reports whether t is excluded.
+func (c *Calendar) Excludes(t time.Time) bool {
+	_, _, ok := c.exclusion(t)
+	return ok
+}
+
+// exclusion returns the bounds of an exclusion t falls into, start inclusive and end exclusive.
+func (c *Calendar) exclusion(t time.Time) (start, end time.Time, ok bool) {
+	if c == nil {
+		return start, end, false
+	}
+	y, m, d := t.Date()
+	key := dateKey(t)
+	for _, days := range c.days {
+		if days.from <= key && key <= days.to {
+			start = time.Date(days.from/10000, time.Month(days.from/100%100), days.from%100, 0, 0, 0, 0, t.Location())
+			end = time.Date(days.to/10000, time.Month(days.to/100%100), days.to%100+1, 0, 0, 0, 0, t.Location())
+			return start, end, true
+		}
+	}
+	for _, p := range c.periods {
+		if !t.Before(p.start) && t.Before(p.end) {
+			return p.start, p.end, true
+		}
+	}
+	for _, w := range c.windows {
+		// a window may start on the day before t
+		for before := 0; before <= 1; before++ {
+			midnight := time.Date(y, m, d-before, 0, 0, 0, 0, t.Location())
+			if midnight.Weekday() != w.weekday {
+				continue
+			}
+			start = time.Date(y, m, d-before, 0, 0, 0, int(w.from), t.Location())
+			end = time.Date(y, m, d-before, 0, 0, 0, int(w.to), t.Location())
+			if !t.Before(start) && t.Before(end) {
+				return start, end, true
+			}
+		}
+	}
+	return start, end, false
+}
+
+func dateKey(date time.Time) int {
+	y, m, d := date.Date()
+	return y*10000 + int(m)*100 + d
+}
+
+// skipExcluded moves next forward past the exclusions of the calendar.
+func (cr *CronExpression) skipExcluded(next time.Time) time.Time {
+	for i := 0; i < maxSteps && !next.IsZero(); i++ {
+		_, end, ok := cr.calendar.exclusion(next)
+		if !ok {
+			return next
+		}
+		if cr.interval > 0 {
+			steps := (end.Sub(next) + cr.interval - 1) / cr.interval
+			next = next.Add(steps * cr.interval)
+		} else {
+			next = cr.nextInLocation(end)
+		}
+	}
+	return time.Time{}
+}
+
+// skipExcludedBackward moves prev backward past the exclusions of the calendar.
+func (cr *CronExpression) skipExcludedBackward(prev time.Time) time.Time {
+	for i := 0; i < maxSteps && !prev.IsZero(); i++ {
+		start, _, ok := cr.calendar.exclusion(prev)
+		if !ok {
+			return prev
+		}
+		if cr.interval > 0 {
+			steps := prev.Sub(start)/cr.interval + 1
+			prev = prev.Add(-steps * cr.interval)
+		} else {
+			prev = cr.prevInLocation(start.Add(-time.Nanosecond))
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,264 @@
+package cron
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	icsDateLayout  = "20060102"
+	icsTimeLayout  = "20060102T150405"
+	icsUTCSuffix   = "Z"
+	icsBeginEvent  = "BEGIN:VEVENT"
+	icsEndEvent    = "END:VEVENT"
+	maxWindowHours = 48
+)
+
+// ParseICS reads the events of an iCalendar file into a calendar. All-day events exclude their days, timed events
+// exclude the instants from DTSTART to DTEND. Recurring events are not supported.
+func ParseICS(r io.Reader) (*Calendar, error) {
+	calendar := &Calendar{}
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	var event map[string]icsProperty
+	for _, line := range lines {
+		switch {
+		case strings.EqualFold(line.text, icsBeginEvent):
+			event = map[string]icsProperty{}
+		case strings.EqualFold(line.text, icsEndEvent):
+			if event == nil {
+				return nil, fmt.Errorf("failed to parse calendar at line %d: unexpected %s", line.number, line.text)
+			}
+			if err := calendar.addEvent(event, line.number); err != nil {
+				return nil, err
+			}
+			event = nil
+		case event != nil:
+			property, ok := parseProperty(line)
+			if !ok {
+				return nil, fmt.Errorf("failed to parse calendar at line %d: invalid property %q", line.number, line.text)
+			}
+			event[property.name] = property
+		}
+	}
+	if event != nil {
+		return nil, fmt.Errorf("failed to parse calendar: missing %s", icsEndEvent)
+	}
+	return calendar, nil
+}
+
+type icsLine struct {
+	text   string
+	number int
+}
+
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+	line   int
+}
+
+// unfold joins the continuation lines, starting with a space or a tab, to the lines they continue.
+func unfold(r io.Reader) ([]icsLine, error) {
+	var lines []icsLine
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if text != "" {
+			lines = append(lines, icsLine{text: text, number: number})
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// parseProperty splits a NAME;PARAM=value:VALUE line.
+func parseProperty(line icsLine) (icsProperty, bool) {
+	colon := strings.Index(line.text, ":")
+	if colon == -1 {
+		return icsProperty{}, false
+	}
+	parts := strings.Split(line.text[:colon], ";")
+	property := icsProperty{
+		name:   strings.ToUpper(parts[0]),
+		params: map[string]string{},
+		value:  line.text[colon+1:],
+		line:   line.number,
+	}
+	for _, param := range parts[1:] {
+		if name, value, ok := strings.Cut(param, "="); ok {
+			property.params[strings.ToUpper(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return property, true
+}
+
+func (c *Calendar) addEvent(event map[string]icsProperty, end int) error {
+	if rrule, ok := event["RRULE"]; ok {
+		return fmt.Errorf("failed to parse calendar at line %d: recurring events are not supported", rrule.line)
+	}
+	dtstart, ok := event["DTSTART"]
+	if !ok {
+		return fmt.Errorf("failed to parse calendar at line %d: missing DTSTART", end)
+	}
+	start, allDay, err := dtstart.time()
+	if err != nil {
+		return err
+	}
+	dtend, ok := event["DTEND"]
+	if !ok {
+		if !allDay {
+			return fmt.Errorf("failed to parse calendar at line %d: missing DTEND", end)
+		}
+		c.AddDate(start)
+		return nil
+	}
+	stop, _, err := dtend.time()
+	if err != nil {
+		return err
+	}
+	if allDay {
+		// DTEND of an all-day event is the day after the last day
+		c.AddDateRange(start, stop.AddDate(0, 0, -1))
+		return nil
+	}
+	c.AddPeriod(start, stop)
+	return nil
+}
+
+// time parses a DATE or DATE-TIME value, times without a TZID or a Z suffix are in the local time zone.
+func (p icsProperty) time() (t time.Time, allDay bool, err error) {
+	if p.params["VALUE"] == "DATE" || len(p.value) == len(icsDateLayout) {
+		t, err = time.Parse(icsDateLayout, p.value)
+		if err != nil {
+			return t, false, fmt.Errorf("failed to parse calendar at line %d: invalid date %q", p.line, p.value)
+		}
+		return t, true, nil
+	}
+	location := time.Local
+	if tzid, ok := p.params["TZID"]; ok {
+		location, err = time.LoadLocation(tzid)
+		if err != nil {
+			return t, false, fmt.Errorf("failed to parse calendar at line %d: %w", p.line, err)
+		}
+	}
+	value := p.value
+	if strings.HasSuffix(value, icsUTCSuffix) {
+		value, location = strings.TrimSuffix(value, icsUTCSuffix), time.UTC
+	}
+	t, err = time.ParseInLocation(icsTimeLayout, value, location)
+	if err != nil {
+		return t, false, fmt.Errorf("failed to parse calendar at line %d: invalid date-time %q", p.line, p.value)
+	}
+	return t, false, nil
+}
+
+type calendarJSON struct {
+	Dates  []string `json:"dates"`
+	Ranges []struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+	} `json:"ranges"`
+	Periods []struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+	} `json:"periods"`
+	Windows []struct {
+		Weekday string `json:"weekday"`
+		From    string `json:"from"`
+		To      string `json:"to"`
+	} `json:"windows"`
+}
+
+// UnmarshalJSON reads either a plain list of dates, e.g. ["2024-12-25", "2024-12-26"], or an object of dates,
+// inclusive date ranges, periods of RFC 3339 instants and weekly windows of wall clock times:
+//
+//	{
+//	  "dates": ["2024-12-25"],
+//	  "ranges": [{"from": "2024-08-01", "to": "2024-08-15"}],
+//	  "periods": [{"start": "2024-03-01T22:00:00Z", "end": "2024-03-02T02:00:00Z"}],
+//	  "windows": [{"weekday": "SUN", "from": "02:00", "to": "04:00"}]
+//	}
+func (c *Calendar) UnmarshalJSON(data []byte) error {
+	var parsed calendarJSON
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		if err := json.Unmarshal(data, &parsed.Dates); err != nil {
+			return err
+		}
+	} else if err := json.Unmarshal(data, &parsed); err != nil {
+		return err
+	}
+	calendar := Calendar{}
+	for _, value := range parsed.Dates {
+		date, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return fmt.Errorf("failed to parse calendar: invalid date %q", value)
+		}
+		calendar.AddDate(date)
+	}
+	for _, r := range parsed.Ranges {
+		from, err := time.Parse(dateLayout, r.From)
+		if err != nil {
+			return fmt.Errorf("failed to parse calendar: invalid date %q", r.From)
+		}
+		to, err := time.Parse(dateLayout, r.To)
+		if err != nil {
+			return fmt.Errorf("failed to parse calendar: invalid date %q", r.To)
+		}
+		calendar.AddDateRange(from, to)
+	}
+	for _, p := range parsed.Periods {
+		calendar.AddPeriod(p.Start, p.End)
+	}
+	for _, w := range parsed.Windows {
+		weekday, err := parseWeekday(token{value: w.Weekday})
+		if err != nil {
+			return fmt.Errorf("failed to parse calendar: invalid weekday %q", w.Weekday)
+		}
+		from, err := parseTimeOfDay(w.From)
+		if err != nil {
+			return err
+		}
+		to, err := parseTimeOfDay(w.To)
+		if err != nil {
+			return err
+		}
+		calendar.AddWeeklyWindow(weekday, from, to)
+	}
+	*c = calendar
+	return nil
+}
+
+// parseTimeOfDay parses HH:MM or HH:MM:SS into an offset from midnight, hours may reach into the next day.
+func parseTimeOfDay(value string) (time.Duration, error) {
+	parts := strings.Split(value, ":")
+	limits := []int{maxWindowHours, 59, 59}
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("failed to parse calendar: invalid time of day %q", value)
+	}
+	var offset time.Duration
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || n > limits[i] {
+			return 0, fmt.Errorf("failed to parse calendar: invalid time of day %q", value)
+		}
+		offset += time.Duration(n) * units[i]
+	}
+	if offset > maxWindowHours*time.Hour {
+		return 0, fmt.Errorf("failed to parse calendar: invalid time of day %q", value)
+	}
+	return offset, nil
+}
@@ -0,0 +1,162 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNextWithCalendar(t *testing.T) {
+	calendar := (&Calendar{}).
+		AddDate(parseTime("2012-12-25 00:00"), parseTime("2012-12-26 00:00")).
+		AddDateRange(parseTime("2012-08-01 00:00"), parseTime("2012-08-15 00:00")).
+		AddPeriod(parseTime("2012-07-10 08:30"), parseTime("2012-07-10 10:00")).
+		AddWeeklyWindow(time.Sunday, 23*time.Hour, 26*time.Hour)
+	tests := []struct {
+		now        string
+		expression string
+		expected   string
+		prev       string
+	}{
+		{"2012-12-24 10:00", "0 0 9 * * *", "2012-12-27 09:00", "2012-12-24 09:00"},
+		{"2012-12-26 10:00", "0 0 9 * * *", "2012-12-27 09:00", "2012-12-24 09:00"},
+		{"2012-07-31 10:00", "0 0 9 * * *", "2012-08-16 09:00", "2012-07-31 09:00"},
+		{"2012-07-10 08:00", "0 0/30 * * * *", "2012-07-10 08:00", "2012-07-10 08:00"},
+		{"2012-07-10 08:15", "0 0/30 * * * *", "2012-07-10 10:00", "2012-07-10 08:00"},
+		{"2012-07-10 09:45", "0 0/30 * * * *", "2012-07-10 10:00", "2012-07-10 08:00"},
+		{"2012-07-15 22:30", "0 0 * * * *", "2012-07-16 02:00", "2012-07-15 22:00"},
+		{"2012-07-16 01:30", "0 0 * * * *", "2012-07-16 02:00", "2012-07-15 22:00"},
+		{"2012-07-10 08:15", "@every 20m", "2012-07-10 10:15", "2012-07-10 07:55"},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression, WithCalendar(calendar))
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			now := parseTime(tt.now)
+			if expected, actual := parseTime(tt.expected), cr.Next(now); actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+			if expected, actual := parseTime(tt.prev), cr.Prev(now); actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+
+	t.Run("iterator", func(t *testing.T) {
+		cr, err := Parse("@every 1h", WithCalendar(calendar))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		assertTimes(t, []string{"2012-07-10 07:00", "2012-07-10 08:00", "2012-07-10 10:00", "2012-07-10 11:00"},
+			cr.Iter(parseTime("2012-07-10 06:00")).Take(4))
+		assertTimes(t, []string{"2012-07-10 11:00", "2012-07-10 10:00", "2012-07-10 08:00", "2012-07-10 07:00"},
+			cr.Between(parseTime("2012-07-10 07:00"), parseTime("2012-07-10 12:00")).Take(4))
+	})
+}
+
+func TestParseICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"SUMMARY:Christmas",
+		"DTSTART;VALUE=DATE:20121225",
+		"DTEND;VALUE=DATE:20121227",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Maintenance window spanning",
+		"  a folded line",
+		"DTSTART;TZID=Europe/Berlin:20121001T020000",
+		"DTEND:20121001T030000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART:20121101",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	calendar, err := ParseICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	tests := []struct {
+		time     string
+		expected bool
+	}{
+		{"2012-12-24 23:59:59", false},
+		{"2012-12-25 00:00", true},
+		{"2012-12-26 23:59:59", true},
+		{"2012-12-27 00:00", false},
+		{"2012-10-01 00:00", true},
+		{"2012-10-01 02:59:59", true},
+		{"2012-10-01 03:00", false},
+		{"2012-11-01 12:00", true},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			if actual := calendar.Excludes(parseTime(tt.time)); actual != tt.expected {
+				t.Errorf("Expected: %v Actual: %v", tt.expected, actual)
+			}
+		})
+	}
+
+	for _, ics := range []string{
+		"BEGIN:VEVENT\nDTSTART:20121101\nRRULE:FREQ=YEARLY\nEND:VEVENT",
+		"BEGIN:VEVENT\nDTSTART:20121101T020000Z\nEND:VEVENT",
+		"BEGIN:VEVENT\nDTSTART:2012-11-01\nEND:VEVENT",
+		"BEGIN:VEVENT\nDTEND:20121101\nEND:VEVENT",
+		"BEGIN:VEVENT\nDTSTART:20121101\n",
+		"BEGIN:VEVENT\nDTSTART;TZID=Mars/Olympus:20121101T020000\nDTEND:20121101T030000Z\nEND:VEVENT",
+	} {
+		t.Run(ics, func(t *testing.T) {
+			if _, err := ParseICS(strings.NewReader(ics)); err == nil {
+				t.Errorf("Expected error for %q", ics)
+			}
+		})
+	}
+}
+
+func TestCalendarUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json     string
+		time     string
+		expected bool
+	}{
+		{`["2012-12-25", "2012-12-26"]`, "2012-12-26 12:00", true},
+		{`["2012-12-25", "2012-12-26"]`, "2012-12-27 12:00", false},
+		{`{"ranges": [{"from": "2012-08-01", "to": "2012-08-15"}]}`, "2012-08-15 23:00", true},
+		{`{"periods": [{"start": "2012-07-10T08:30:00Z", "end": "2012-07-10T10:00:00Z"}]}`, "2012-07-10 09:00", true},
+		{`{"windows": [{"weekday": "SUN", "from": "02:00", "to": "04:00:30"}]}`, "2012-07-15 04:00:15", true},
+		{`{"windows": [{"weekday": "0", "from": "02:00", "to": "04:00"}]}`, "2012-07-16 03:00", false},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			var calendar Calendar
+			if err := json.Unmarshal([]byte(tt.json), &calendar); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := calendar.Excludes(parseTime(tt.time)); actual != tt.expected {
+				t.Errorf("Expected: %v Actual: %v", tt.expected, actual)
+			}
+		})
+	}
+
+	for _, data := range []string{
+		`["2012-13-01"]`,
+		`{"ranges": [{"from": "2012-08-01", "to": "tomorrow"}]}`,
+		`{"windows": [{"weekday": "FUNDAY", "from": "02:00", "to": "04:00"}]}`,
+		`{"windows": [{"weekday": "SUN", "from": "2am", "to": "04:00"}]}`,
+		`{"windows": [{"weekday": "SUN", "from": "02:00", "to": "49:00"}]}`,
+		`{"dates": "2012-12-25"}`,
+	} {
+		t.Run(data, func(t *testing.T) {
+			var calendar Calendar
+			if err := json.Unmarshal([]byte(data), &calendar); err == nil {
+				t.Errorf("Expected error for %s", data)
+			}
+		})
+	}
+}
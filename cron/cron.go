@@ -1,11 +1,13 @@
 package cron
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/vestverg/baymax/bits"
 )
@@ -19,9 +21,28 @@ const (
 	DOM // day of month
 	Month
 	DOW // day of week
-
+	Year
+	Millisecond
 )
 
+var fieldNames = map[CronFieldType]string{
+	Second:      "seconds",
+	Minute:      "minutes",
+	Hour:        "hours",
+	DOM:         "days of month",
+	Month:       "months",
+	DOW:         "days of week",
+	Year:        "years",
+	Millisecond: "milliseconds",
+}
+
+func (ft CronFieldType) String() string {
+	if name, ok := fieldNames[ft]; ok {
+		return name
+	}
+	return strconv.Itoa(int(ft))
+}
+
 var fieldRange = map[CronFieldType]FieldRange{
 	Second: {
 		min: 0,
@@ -58,7 +79,7 @@ var fieldRange = map[CronFieldType]FieldRange{
 		},
 	},
 	DOW: {
-		min: 1,
+		min: 0,
 		max: 7,
 		names: map[string]int{
 			"sun": 0,
@@ -70,6 +91,14 @@ var fieldRange = map[CronFieldType]FieldRange{
 			"sat": 6,
 		},
 	},
+	Year: {
+		min: 1970,
+		max: 2099,
+	},
+	Millisecond: {
+		min: 0,
+		max: 999,
+	},
 }
 
 type FieldRange struct {
@@ -85,7 +114,7 @@ func (fr *FieldRange) IsValid(value int) bool {
 	return true
 }
 
-//CronExpression Parse the given crontab expression  string into a CronExpression. The string has six single space-separated cron and date fields:
+//CronExpression Parse the given crontab expression  string into a CronExpression. The string has six space-separated cron and date fields:
 //┌───────────── second (0-59)
 //│ ┌───────────── minute (0 - 59)
 //│ │ ┌───────────── hour (0 - 23)
@@ -95,6 +124,10 @@ func (fr *FieldRange) IsValid(value int) bool {
 //│ │ │ │ │ │          (0 or 7 is Sunday, or MON-SUN)
 //│ │ │ │ │ │
 //* * * * * *
+//A classic five-field Unix expression without seconds (minute hour dom month dow) is accepted as well and fires at second 0.
+//A seventh Quartz-style year field (1970-2099) may follow the day of week; once the years are exhausted Next returns the zero time.
+//With WithMilliseconds a leading millisecond field (0-999) precedes the seconds.
+//The expression may be prefixed with CRON_TZ=<location> (or TZ=<location>) to evaluate it in the given time zone, see WithLocation.
 //The following rules apply:
 //A field may be an asterisk ( *), which always stands for "first-last". For the "day of the month" or "day of the week" fields, a question mark ( ?) may be used instead of an asterisk.
 //Ranges of numbers are expressed by two numbers separated with a hyphen ( -). The specified range is inclusive.
@@ -105,39 +138,172 @@ func (fr *FieldRange) IsValid(value int) bool {
 //In the "day of week" field, L stands for "the last day of the week". If prefixed by a number or three-letter name (i.e. dL or DDDL), it means "the last day of week d (or DDD) in the month".
 //The "day of month" field can be nW, which stands for "the nearest weekday to day of the month n". If n falls on Saturday, this yields the Friday before it. If n falls on Sunday, this yields the Monday after, which also happens if n is 1 and falls on a Saturday (i.e. 1W stands for "the first weekday of the month").
 //The "day of week" field can be d#n (or DDD#n), which stands for "the n-th day of week d (or DDD) in the month".
+//A field may be H, H(a-b), H/n or H(a-b)/n which picks a value (or a step offset) in the field range, or a-b, by the hash of the seed set with WithHashSeed.
+//A field may be R or R(a-b) which picks a random value in the field range, or a-b, once per Parse, see WithRandSource.
+//Instead of the fields one of the macros may be used: @yearly (or @annually), @monthly, @weekly, @daily (or @midnight), @hourly,
+//or @every <duration> (i.e. @every 1h30m) which fires at a fixed interval, the duration is parsed by time.ParseDuration.
 
 type CronExpression struct {
 	fields     []CronField
 	expression string
+	interval   time.Duration
+	options
 }
 
+// Next returns the first time at or after from matching the expression. For @every expressions it returns from shifted by the interval.
+// With WithJitter the result is delayed by a random duration.
+// The result is in the location of the expression, or in the location of from if the expression has none.
+// The zero time is returned when there is no such time, or for the zero CronExpression.
 func (cr *CronExpression) Next(from time.Time) time.Time {
-	const maxIterations = 366
-	for i := 0; i < maxIterations; i++ {
-		next := from
-		for _, field := range cr.fields {
-			next = field.NextOrSame(next)
+	return cr.addJitter(cr.next(from))
+}
+
+// next is Next without the jitter.
+func (cr *CronExpression) next(from time.Time) time.Time {
+	if cr.isZero() {
+		return time.Time{}
+	}
+	var next time.Time
+	if cr.interval > 0 {
+		next = from.Add(cr.interval)
+	} else {
+		next = cr.nextInLocation(from)
+	}
+	return cr.skipExcluded(next)
+}
+
+// addJitter delays a fire time by a random duration up to the jitter of WithJitter.
+func (cr *CronExpression) addJitter(t time.Time) time.Time {
+	if cr.jitter > 0 && !t.IsZero() {
+		t = t.Add(time.Duration(cr.random.Int63n(int64(cr.jitter))))
+	}
+	return t
+}
+
+// nextWall finds the next matching wall clock time, from must be in UTC.
+// Like prevWall it jumps to the next set bit of each field, carrying into the higher fields. The Gregorian calendar
+// repeats every 400 years, so when nothing matches within them the expression never fires again and the zero time
+// is returned.
+func (cr *CronExpression) nextWall(from time.Time) time.Time {
+	t := from
+	months, dom, either := cr.field(Month), cr.field(DOM), cr.eitherDay()
+	hours, minutes, seconds, millis := cr.field(Hour), cr.field(Minute), cr.field(Second), cr.field(Millisecond)
+	limit := from.Year() + 400
+	for t.Year() <= limit {
+		y, m, d := t.Date()
+		if years := cr.field(Year); years != nil {
+			year := years.nextInList(y)
+			if year == -1 {
+				return time.Time{}
+			}
+			if year != y {
+				t = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+				continue
+			}
+		}
+		if month := months.Next(int(m)); month != int(m) {
+			if month == -1 {
+				t = time.Date(y+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+			} else {
+				t = time.Date(y, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+			}
+			continue
+		}
+		if len(dom.rules) == 0 && !either {
+			if day := dom.Next(d); day != d {
+				if day == -1 || day > daysIn(t) {
+					t = time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC)
+				} else {
+					t = time.Date(y, m, day, 0, 0, 0, 0, time.UTC)
+				}
+				continue
+			}
+		}
+		if !cr.matchesDays(t) {
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if hour := hours.Next(t.Hour()); hour != t.Hour() {
+			if hour == -1 {
+				t = time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC)
+			} else {
+				t = time.Date(y, m, d, hour, 0, 0, 0, time.UTC)
+			}
+			continue
+		}
+		if minute := minutes.Next(t.Minute()); minute != t.Minute() {
+			if minute == -1 {
+				t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, time.UTC)
+			} else {
+				t = time.Date(y, m, d, t.Hour(), minute, 0, 0, time.UTC)
+			}
+			continue
 		}
-		if from.Equal(next) {
-			return next
+		if second := seconds.Next(t.Second()); second != t.Second() {
+			if second == -1 {
+				t = time.Date(y, m, d, t.Hour(), t.Minute()+1, 0, 0, time.UTC)
+			} else {
+				t = time.Date(y, m, d, t.Hour(), t.Minute(), second, 0, time.UTC)
+			}
+			continue
 		}
-		from = next
+		if millis != nil {
+			current := millis.getPartOfTime(t)
+			if ms := millis.nextInList(current); ms != current {
+				if ms == -1 {
+					t = time.Date(y, m, d, t.Hour(), t.Minute(), t.Second()+1, 0, time.UTC)
+				} else {
+					t = time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), ms*int(time.Millisecond), time.UTC)
+				}
+				continue
+			}
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// isZero reports whether the expression is the zero value, e.g. an unset CronExpression field of a config struct.
+func (cr *CronExpression) isZero() bool {
+	return cr.fields == nil && cr.interval == 0
+}
+
+// precision returns the finest unit of the expression, fire times are multiples of it.
+func (cr *CronExpression) precision() time.Duration {
+	if cr.field(Millisecond) != nil {
+		return time.Millisecond
 	}
-	return from
+	return time.Second
+}
+
+// Matches reports whether the expression fires at t, like Next it applies the location, the daylight saving time
+// policies and the calendar of the expression, but not the jitter. @every expressions fire relative to the time
+// they are asked from, so they match nothing.
+func (cr *CronExpression) Matches(t time.Time) bool {
+	if cr.interval > 0 || cr.isZero() {
+		return false
+	}
+	next := cr.nextInLocation(t)
+	return next.Equal(t) && !cr.calendar.Excludes(next)
+}
+
+// Field returns the values of a field in ascending order, Sunday is 0. Day rules like L, W and # aren't values.
+// It returns nil for the year and millisecond fields if the expression has none, i.e. it fires every year or at
+// millisecond 0, and for @every expressions.
+func (cr *CronExpression) Field(fieldType CronFieldType) []int {
+	field := cr.field(fieldType)
+	if field == nil {
+		return nil
+	}
+	return field.values()
 }
 
 type CronField struct {
 	fieldType  CronFieldType
 	fieldRange FieldRange
 	bits       int64
-}
-
-func (cr *CronField) getHigherFieldType() CronFieldType {
-	fieldType := cr.fieldType + 1
-	if fieldType >= 6 {
-		fieldType = Month
-	}
-	return fieldType
+	rules      []dayRule
+	list       []int // values of list fields, see isListField
 }
 
 func Reset(ft CronFieldType, date time.Time) time.Time {
@@ -154,44 +320,55 @@ func Reset(ft CronFieldType, date time.Time) time.Time {
 		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
 	case DOW:
 		return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	case Millisecond:
+		return date.Truncate(time.Millisecond)
 	}
 	return date
 }
 
+// NextOrSame returns the date if the field matches it, otherwise the start of the next unit of the field that matches,
+// like the start of the next matching hour. It checks the field alone, CronExpression.Next searches all fields at once.
 func (cr *CronField) NextOrSame(date time.Time) time.Time {
-	current := cr.getPartOfTime(date)
-	next := cr.Next(current)
-	if next == -1 {
-		date = cr.shift(date)
-		next = cr.Next(0)
-	}
-	if next == current {
+	switch {
+	case cr.isDayField():
+		// day rules and month lengths make the day fields irregular, so it steps day by day
+		for i := 0; i < 366 && !cr.matchesDay(date); i++ {
+			date = time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, date.Location())
+		}
 		return date
-	} else {
-		current = cr.getPartOfTime(date)
-		count := 0
-		for current != next && count < 366 {
-			date = cr.evaluateNext(date, next)
-			current = cr.getPartOfTime(date)
-			next = cr.Next(current)
-			if next == -1 {
-				date = cr.shift(date)
-				next = cr.Next(0)
-			}
-			count++
+	case cr.fieldType == Year:
+		switch year := cr.nextInList(date.Year()); year {
+		case -1:
+			return time.Time{}
+		case date.Year():
+			return date
+		default:
+			return time.Date(year, time.January, 1, 0, 0, 0, 0, date.Location())
 		}
-		date = Reset(cr.fieldType, date)
+	}
+	for i := cr.fieldRange.min; i <= cr.fieldRange.max && !cr.contains(cr.getPartOfTime(date)); i++ {
+		date = cr.Add(Reset(cr.fieldType, date), 1)
 	}
 	return date
 }
 
-func (cr *CronField) evaluateNext(date time.Time, next int) time.Time {
-	current := cr.getPartOfTime(date)
-	if current < next {
-		date = cr.Add(date, next-current)
-	} else {
-		amount := cr.fieldRange.max - current + next + 1 - cr.fieldRange.min
-		date = cr.Add(date, amount)
+// Add adds value units of the field to the date, days and months keep the time of day.
+func (cr *CronField) Add(date time.Time, value int) time.Time {
+	switch cr.fieldType {
+	case Millisecond:
+		return date.Add(time.Duration(value) * time.Millisecond)
+	case Second:
+		return date.Add(time.Duration(value) * time.Second)
+	case Minute:
+		return date.Add(time.Duration(value) * time.Minute)
+	case Hour:
+		return date.Add(time.Duration(value) * time.Hour)
+	case DOM, DOW:
+		return time.Date(date.Year(), date.Month(), date.Day()+value, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+	case Month:
+		return time.Date(date.Year(), date.Month()+time.Month(value), date.Day(), date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+	case Year:
+		return time.Date(date.Year()+value, date.Month(), date.Day(), date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
 	}
 	return date
 }
@@ -210,6 +387,10 @@ func (cr *CronField) getPartOfTime(date time.Time) int {
 		return int(date.Month())
 	case DOW:
 		return int(date.Weekday())
+	case Year:
+		return date.Year()
+	case Millisecond:
+		return date.Nanosecond() / int(time.Millisecond)
 	}
 	return -1
 
@@ -228,186 +409,348 @@ func (cr *CronField) Next(idx int) int {
 }
 
 func (cr *CronField) setBits(fr *FieldRange) {
+	if cr.isListField() {
+		for value := fr.min; value <= fr.max; value++ {
+			cr.addToList(value)
+		}
+		return
+	}
 	if fr.min == fr.max {
 		cr.setBit(fr.min)
 		return
 	}
-	minMask := math.MaxInt64 << fr.min
-	maxMask := math.MaxInt64 >> (fr.max + 1)
-	cr.bits |= int64(minMask & maxMask)
+	minMask := int64(math.MaxInt64) << fr.min
+	maxMask := int64(math.MaxInt64) >> (62 - fr.max)
+	cr.bits |= minMask & maxMask
 
 }
 
 func (cr *CronField) setBit(idx int) {
+	if cr.isListField() {
+		cr.addToList(idx)
+		return
+	}
 	cr.bits = bits.SetBit(cr.bits, idx)
 }
 
-func (cr *CronField) shift(date time.Time) time.Time {
-	switch cr.fieldType {
-	case Minute:
-		return Reset(cr.getHigherFieldType(), date.Add(time.Hour))
-	case Second:
-		return Reset(cr.getHigherFieldType(), date.Add(time.Minute))
-	case Hour:
-		return Reset(cr.getHigherFieldType(), date.Add(24*time.Hour))
-	case DOM: //???
-		y, m, _ := date.Date()
-		return time.Date(y, m+1, 1, 0, 0, 0, 0, date.Location())
-	case DOW:
-		cr.Add(date, int(date.Weekday()-time.Monday))
-	case Month:
-		y, _, _ := date.Date()
-		return time.Date(y+1, 1, 1, 0, 0, 0, 0, date.Location())
+func Parse(value string, opts ...Option) (*CronExpression, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
 	}
-	return date
-
+	if o.jitter > 0 {
+		o.rand()
+	}
+	cr, err := parse(value, &o)
+	if err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Expression = value
+		}
+		return nil, err
+	}
+	cr.expression = value
+	cr.options = o
+	return cr, nil
 }
 
-func (cr *CronField) Add(date time.Time, value int) time.Time {
-	switch cr.fieldType {
-	case Minute:
-		return date.Add(time.Duration(value) * time.Minute)
-	case Second:
-		return date.Add(time.Duration(value) * time.Second)
-	case Hour:
-		return date.Add(time.Duration(value) * time.Hour)
-	case DOM, DOW:
-		return time.Date(date.Year(), date.Month(), date.Day()+value, date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
-	case Month:
-		return time.Date(date.Year(), date.Month()+time.Month(value), date.Day(), date.Hour(), date.Minute(), date.Second(), date.Nanosecond(), date.Location())
+// parse stores the location of a CRON_TZ prefix in o.
+func parse(value string, o *options) (*CronExpression, error) {
+	tokens := fields(token{value: value})
+	if len(tokens) == 0 {
+		return nil, &ParseError{Reason: ReasonEmpty}
 	}
-	return date
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if !strings.HasPrefix(tokens[0].value, prefix) {
+			continue
+		}
+		location, err := time.LoadLocation(tokens[0].value[len(prefix):])
+		if err != nil {
+			parseErr := newParseError(0, tokens[0], ReasonInvalidLocation)
+			parseErr.Err = err
+			return nil, parseErr
+		}
+		o.location = location
+		tokens = tokens[1:]
+		break
+	}
+	if len(tokens) == 0 {
+		return nil, &ParseError{Offset: len(value), Reason: ReasonFieldCount}
+	}
+	if strings.HasPrefix(tokens[0].value, "@") {
+		return parseMacro(tokens, o)
+	}
+	return parseFields(tokens, o)
+}
 
+// token is a part of the expression and its byte offset in the expression.
+type token struct {
+	value  string
+	offset int
 }
 
-func Parse(value string) (*CronExpression, error) {
-	if value == "" {
-		return nil, fmt.Errorf("expression string must not be empty")
+// fields splits the token around whitespace.
+func fields(t token) []token {
+	var tokens []token
+	start := -1
+	for i, r := range t.value {
+		if unicode.IsSpace(r) {
+			if start != -1 {
+				tokens = append(tokens, token{value: t.value[start:i], offset: t.offset + start})
+				start = -1
+			}
+		} else if start == -1 {
+			start = i
+		}
 	}
-	value = strings.Replace(value, "?", "*", -1)
-	//todo: add macros
-	fields := strings.Split(value, " ")
-	if len(fields) != 6 {
-		return nil, fmt.Errorf("invalid cron expression, cron expression must consist of 6 fields")
+	if start != -1 {
+		tokens = append(tokens, token{value: t.value[start:], offset: t.offset + start})
 	}
-	seconds, err := parseField(fields[0], Second)
+	return tokens
+}
+
+// split slices the token around sep, keeping empty tokens.
+func split(t token, sep string) []token {
+	var tokens []token
+	offset := t.offset
+	for _, value := range strings.Split(t.value, sep) {
+		tokens = append(tokens, token{value: value, offset: offset})
+		offset += len(value) + len(sep)
+	}
+	return tokens
+}
+
+func parseFields(tokens []token, o *options) (*CronExpression, error) {
+	var millis *CronField
+	if o.milliseconds {
+		if len(tokens) < 7 || len(tokens) > 8 {
+			last := tokens[len(tokens)-1]
+			return nil, &ParseError{Offset: last.offset + len(last.value), Reason: ReasonFieldCount}
+		}
+		var err error
+		if millis, err = parseField(tokens[0], Millisecond, o); err != nil {
+			return nil, err
+		}
+		tokens = tokens[1:]
+	}
+	switch len(tokens) {
+	case 5:
+		// classic Unix format without seconds
+		tokens = append([]token{{value: "0", offset: tokens[0].offset}}, tokens...)
+	case 6, 7:
+	default:
+		last := tokens[len(tokens)-1]
+		return nil, &ParseError{Offset: last.offset + len(last.value), Reason: ReasonFieldCount}
+	}
+	seconds, err := parseField(tokens[0], Second, o)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse seconds:f %w", err)
+		return nil, err
 	}
-	minutes, err := parseField(fields[1], Minute)
+	minutes, err := parseField(tokens[1], Minute, o)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse minutes: %w", err)
+		return nil, err
 	}
-	hours, err := parseField(fields[2], Hour)
+	hours, err := parseField(tokens[2], Hour, o)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse hours: %w", err)
+		return nil, err
 	}
-	dom, err := parseField(fields[3], DOM)
+	dom, err := parseField(tokens[3], DOM, o)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse days of moth: %w", err)
+		return nil, err
 	}
-	months, err := parseField(fields[4], Month)
+	months, err := parseField(tokens[4], Month, o)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse months: %w", err)
+		return nil, err
 	}
-	dow, err := parseField(fields[5], DOW)
+	dow, err := parseField(tokens[5], DOW, o)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse days of week: %w", err)
+		return nil, err
 	}
 
-	return &CronExpression{
-		fields:     []CronField{*dow, *months, *dom, *hours, *minutes, *seconds},
-		expression: value,
-	}, nil
+	cronFields := []CronField{*dow, *months, *dom, *hours, *minutes, *seconds}
+	if len(tokens) == 7 && tokens[6].value != "*" {
+		years, err := parseField(tokens[6], Year, o)
+		if err != nil {
+			return nil, err
+		}
+		cronFields = append([]CronField{*years}, cronFields...)
+	}
+	if millis != nil {
+		cronFields = append(cronFields, *millis)
+	}
+
+	if o.dayPolicy == DayQuartz && !dom.isFull() && !dow.isFull() {
+		return nil, newParseError(DOW, tokens[5], ReasonDayConflict)
+	}
+	cr := &CronExpression{
+		fields:  cronFields,
+		options: *o,
+	}
+	if cr.neverFires() {
+		return nil, &ParseError{Offset: tokens[3].offset, Reason: ReasonNeverFires}
+	}
+	return cr, nil
+}
+
+// neverFires reports whether no date matches the day, month and year fields, e.g. 0 0 0 30 2 *.
+// The search covers the whole 400 year cycle of the calendar.
+func (cr *CronExpression) neverFires() bool {
+	return cr.nextWall(time.Date(fieldRange[Year].min, time.January, 1, 0, 0, 0, 0, time.UTC)).IsZero()
+}
+
+var macros = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
 }
 
-func parseField(field string, fieldType CronFieldType) (*CronField, error) {
-	if field == "" {
-		return nil, fmt.Errorf("field is empty")
+const everyMacro = "@every"
+
+func parseMacro(tokens []token, o *options) (*CronExpression, error) {
+	macro := strings.ToLower(tokens[0].value)
+	if macro == everyMacro {
+		if len(tokens) != 2 {
+			return nil, newParseError(0, tokens[0], ReasonInvalidInterval)
+		}
+		interval, err := time.ParseDuration(tokens[1].value)
+		if err != nil || interval <= 0 {
+			parseErr := newParseError(0, tokens[1], ReasonInvalidInterval)
+			parseErr.Err = err
+			return nil, parseErr
+		}
+		return &CronExpression{interval: interval}, nil
+	}
+	expression, ok := macros[macro]
+	if !ok || len(tokens) != 1 {
+		return nil, newParseError(0, tokens[0], ReasonUnknownMacro)
+	}
+	if o.milliseconds {
+		expression = "0 " + expression
 	}
+	return parseFields(fields(token{value: expression}), o)
+}
+
+func parseField(field token, fieldType CronFieldType, o *options) (*CronField, error) {
 	fr := fieldRange[fieldType]
 	cronField := CronField{fieldType: fieldType, fieldRange: fr}
-	parts := strings.Split(field, ",")
-	for _, part := range parts {
-		slash := strings.Index(part, "/")
+	if cronField.isDayField() && field.value == "?" {
+		field.value = "*"
+	}
+	for _, part := range split(field, ",") {
+		if fieldType == DOW && strings.EqualFold(part.value, "L") {
+			// last day of the week
+			part.value = "SAT"
+		}
+		values, ok, err := parseHash(part, fieldType, o)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			values, ok, err = parseRandom(part, fieldType, o)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if ok {
+			for _, value := range values {
+				cronField.setBit(value)
+			}
+			continue
+		}
+		rule, ok, err := parseDayRule(part, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			cronField.rules = append(cronField.rules, *rule)
+			continue
+		}
+		slash := strings.Index(part.value, "/")
 		if slash == -1 {
 			r, err := parseRange(part, fieldType)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse field %w", err)
+				return nil, err
 			}
 			cronField.setBits(r)
 		} else {
-			rangeStr := part[:slash]
-
-			r, err := parseRange(rangeStr, fieldType)
+			rangeToken := token{value: part.value[:slash], offset: part.offset}
+			r, err := parseRange(rangeToken, fieldType)
 			if err != nil {
-				return nil, fmt.Errorf("failed to parse field %w", err)
+				return nil, err
 			}
-			if strings.Index(rangeStr, "-") == -1 {
+			if !strings.Contains(rangeToken.value, "-") {
 				// if it's not interval
 				r = &FieldRange{min: r.min, max: fr.max}
 			}
 
-			deltaStr := part[slash+1:]
-			delta, err := strconv.Atoi(deltaStr)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse delta %w", err)
-			}
-			if delta <= 0 {
-				return nil, fmt.Errorf("delta is negative")
-			}
-			if delta == 1 {
-				cronField.setBits(r)
+			deltaToken := token{value: part.value[slash+1:], offset: part.offset + slash + 1}
+			delta, err := strconv.Atoi(deltaToken.value)
+			if err != nil || delta <= 0 {
+				return nil, newParseError(fieldType, deltaToken, ReasonInvalidStep)
 			}
 			for i := r.min; i <= r.max; i += delta {
 				cronField.setBit(i)
 			}
 		}
 	}
+	if fieldType == DOW && cronField.GetBit(7) == 1 {
+		// 0 and 7 are both Sunday
+		cronField.bits &^= 1 << 7
+		cronField.setBit(0)
+	}
 	return &cronField, nil
 }
 
-func parseRange(val string, fieldType CronFieldType) (*FieldRange, error) {
-	fr, ok := fieldRange[fieldType]
-	if !ok {
-		return nil, fmt.Errorf("unknown field type")
-	}
-	if val == "*" {
+func parseRange(t token, fieldType CronFieldType) (*FieldRange, error) {
+	fr := fieldRange[fieldType]
+	if t.value == "*" {
 		return &fr, nil
 	}
-	hypen := strings.Index(val, "-")
-	if hypen == -1 {
-		res, err := parseNameOrVal(val, fr)
+	hyphen := strings.Index(t.value, "-")
+	if hyphen == -1 {
+		res, err := parseValue(t, fieldType)
 		if err != nil {
-			return nil, fmt.Errorf("invalid range value")
-		}
-		if !fr.IsValid(res) {
-			return nil, fmt.Errorf("value out of range")
+			return nil, err
 		}
 		return &FieldRange{min: res, max: res}, nil
 	}
 
-	min, err := parseNameOrVal(val[0:hypen], fr)
+	min, err := parseValue(token{value: t.value[:hyphen], offset: t.offset}, fieldType)
 	if err != nil {
-		return nil, fmt.Errorf("invalid min range value")
-	}
-	if !fr.IsValid(min) {
-		return nil, fmt.Errorf("min value out of range")
+		return nil, err
 	}
-
-	max, err := parseNameOrVal(val[hypen+1:], fr)
+	max, err := parseValue(token{value: t.value[hyphen+1:], offset: t.offset + hyphen + 1}, fieldType)
 	if err != nil {
-		return nil, fmt.Errorf("invalid max range value")
+		return nil, err
+	}
+	if fieldType == DOW && max == 0 && min > 0 {
+		// Sunday as the end of a range like MON-SUN is 7
+		max = 7
 	}
-	if !fr.IsValid(min) {
-		return nil, fmt.Errorf("max value out of range")
+	if min > max {
+		return nil, newParseError(fieldType, t, ReasonInvalidRange)
 	}
 
 	return &FieldRange{
 		min: min,
 		max: max,
 	}, nil
+}
 
+func parseValue(t token, fieldType CronFieldType) (int, error) {
+	fr := fieldRange[fieldType]
+	v, err := parseNameOrVal(t.value, fr)
+	if err != nil {
+		return -1, newParseError(fieldType, t, ReasonInvalidValue)
+	}
+	if !fr.IsValid(v) {
+		return -1, newParseError(fieldType, t, ReasonOutOfRange)
+	}
+	return v, nil
 }
 
 func parseNameOrVal(val string, fr FieldRange) (int, error) {
@@ -2,6 +2,7 @@ package cron
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -19,6 +20,7 @@ func TestNext(t *testing.T) {
 		{"2012-07-09 14:46", "0 0/15 * * * *", "2012-07-09 15:00"},
 		{"2012-07-09 14:59", "0 0/15 * * * *", "2012-07-09 15:00"},
 		{"2012-07-09 14:59:59", "0 0/15 * * * *", "2012-07-09 15:00"},
+		{"2012-07-09 14:59:59.5", "* * * * * *", "2012-07-09 15:00"},
 
 		// shift hours
 		{"2012-07-09 15:45", "0 20-35/15 * * * *", "2012-07-09 16:20"},
@@ -48,6 +50,36 @@ func TestNext(t *testing.T) {
 
 		// Leap year
 		{"2012-07-09 23:35", "0 0 0 29 Feb ?", "2016-02-29 00:00"},
+
+		// Sunday is both 0 and 7
+		{"2012-07-09 23:35", "0 0 0 * * 0", "2012-07-15 00:00"},
+		{"2012-07-09 23:35", "0 0 0 * * 7", "2012-07-15 00:00"},
+		{"2012-07-09 23:35", "0 0 0 * * Sun", "2012-07-15 00:00"},
+
+		// Last day of month
+		{"2012-02-10 00:00", "0 0 12 L * *", "2012-02-29 12:00"},
+		{"2013-02-10 00:00", "0 0 12 L * *", "2013-02-28 12:00"},
+		{"2012-07-09 23:35", "0 0 0 L-2 * *", "2012-07-29 00:00"},
+		{"2012-09-01 00:00", "0 0 0 LW * *", "2012-09-28 00:00"},
+
+		// Nearest weekday
+		{"2012-09-01 00:00", "0 0 0 15W * *", "2012-09-14 00:00"},
+		{"2012-09-01 00:00", "0 0 0 1W * *", "2012-09-03 00:00"},
+		{"2012-09-01 00:00", "0 0 0 30W * *", "2012-09-28 00:00"},
+		{"2012-09-01 00:00", "0 0 0 31W * *", "2012-10-31 00:00"},
+
+		// Last and n-th day of week in month
+		{"2012-07-09 23:35", "0 0 0 * * L", "2012-07-14 00:00"},
+		{"2012-07-09 23:35", "0 0 0 * * 5L", "2012-07-27 00:00"},
+		{"2012-07-09 23:35", "0 0 0 * * FRIL", "2012-07-27 00:00"},
+		{"2012-07-09 14:45", "0 0 9 * * MON#2", "2012-08-13 09:00"},
+		{"2012-07-09 08:00", "0 0 9 * * Mon#2", "2012-07-09 09:00"},
+		{"2012-07-09 23:35", "0 0 0 * * 1#5", "2012-07-30 00:00"},
+		{"2012-07-31 23:35", "0 0 0 * * 1#5", "2012-10-29 00:00"},
+
+		// Sparse
+		{"2012-07-09 23:35", "0 0 0 29 2 MON", "2016-02-29 00:00"},
+		{"2096-03-01 00:00", "0 0 0 29 2 *", "2104-02-29 00:00"},
 	}
 	for i, tt := range tests {
 		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
@@ -64,10 +96,354 @@ func TestNext(t *testing.T) {
 	}
 }
 
+func TestNextFieldFormats(t *testing.T) {
+	tests := []struct {
+		now        string
+		expression string
+		expected   string
+	}{
+		// Unix format
+		{"2012-07-09 14:46", "*/15 * * * *", "2012-07-09 15:00"},
+		{"2012-07-14 14:46", "0 9 * * 1-5", "2012-07-16 09:00"},
+		{"2012-07-09 14:46:30", "* * * * *", "2012-07-09 14:47"},
+		{"2012-07-09 14:46", "0  9  *  *  MON", "2012-07-16 09:00"},
+		{"2012-07-14 14:46", "0 9 * * MON-SUN", "2012-07-15 09:00"},
+		{"2012-07-13 14:46", "0 9 * * SAT-SUN", "2012-07-14 09:00"},
+
+		// Quartz format
+		{"2012-07-09 23:35", "0 0 0 1 1 * *", "2013-01-01 00:00"},
+		{"2012-07-09 23:35", "0 0 0 1 1 * 2014", "2014-01-01 00:00"},
+		{"2013-03-01 00:00", "0 0 12 * * ? 2012/2", "2014-01-01 12:00"},
+		{"2012-07-09 23:35", "0 0 0 29 2 ? 2013-2017", "2016-02-29 00:00"},
+		{"2012-07-09 23:35", "0 0 0 * * * 2010,2012", "2012-07-10 00:00"},
+
+		// Years exhausted
+		{"2014-01-01 00:00:01", "0 0 0 1 1 * 2013-2014", ""},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			actual := cr.Next(parseTime(tt.now))
+			expected := parseTime(tt.expected)
+			if actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+
+	for _, expression := range []string{"* * * *", "0 0 0 * * * * *", "0 0 0 * * * 1969", "0 0 0 * * * 2100", "0 0 0 * * * 20x0"} {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Parse(expression); err == nil {
+				t.Errorf("Expected error for %q", expression)
+			}
+		})
+	}
+}
+
+func TestMacros(t *testing.T) {
+	tests := []struct {
+		now        string
+		expression string
+		expected   string
+	}{
+		{"2012-07-09 23:35", "@yearly", "2013-01-01 00:00"},
+		{"2012-07-09 23:35", "@annually", "2013-01-01 00:00"},
+		{"2012-07-09 23:35", "@monthly", "2012-08-01 00:00"},
+		{"2012-07-09 23:35", "@weekly", "2012-07-15 00:00"},
+		{"2012-07-09 23:35", "@daily", "2012-07-10 00:00"},
+		{"2012-07-09 23:35", "@MIDNIGHT", "2012-07-10 00:00"},
+		{"2012-07-09 23:35", "@hourly", "2012-07-10 00:00"},
+		{"2012-07-09 23:35", "@every 90s", "2012-07-09 23:36:30"},
+		{"2012-07-09 23:35", "@every 1h30m", "2012-07-10 01:05"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			cr, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			actual := cr.Next(parseTime(tt.now))
+			expected := parseTime(tt.expected)
+			if actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+
+	for _, expression := range []string{"@fortnightly", "@every", "@every 1x", "@every -1s", "@every 0s"} {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Parse(expression); err == nil {
+				t.Errorf("Expected error for %q", expression)
+			}
+		})
+	}
+}
+
+func TestParseDayRulesErrors(t *testing.T) {
+	tests := []string{
+		"0 0 0 L-31 * *",
+		"0 0 0 32W * *",
+		"0 0 0 W * *",
+		"0 0 0 * * MON#6",
+		"0 0 0 * * MON#0",
+		"0 0 0 * * 8L",
+		"0 0 0 * * XYZ#1",
+	}
+	for _, expression := range tests {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Parse(expression); err == nil {
+				t.Errorf("Expected error for %q", expression)
+			}
+		})
+	}
+}
+
+func TestParseNeverFires(t *testing.T) {
+	tests := []string{
+		"0 0 0 30 2 *",
+		"0 0 30 FEB *",
+		"0 0 0 31 4,6,9,11 *",
+		"0 0 0 L-29 2 *",
+		"0 0 0 29 2 ? 2013-2015",
+		"0 0 0 29 2 MON 2012-2015",
+		"0 0 0 13 2 FRI 2014",
+	}
+	for _, expression := range tests {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Parse(expression); err == nil {
+				t.Errorf("Expected error for %q", expression)
+			}
+		})
+	}
+}
+
 func parseTime(val string) time.Time {
 	parsed, err := time.Parse("2006-01-02 15:04", val)
 	if err != nil {
-		parsed, _ = time.Parse("2006-01-02 15:04:05", val)
+		parsed, _ = time.Parse("2006-01-02 15:04:05.999999999", val)
 	}
 	return parsed
 }
+
+func BenchmarkNext(b *testing.B) {
+	benchmarks := []struct {
+		name       string
+		expression string
+	}{
+		{"EverySecond", "* * * * * *"},
+		{"Hourly", "0 0 * * * *"},
+		{"Weekdays", "0 30 9 * * MON-FRI"},
+		{"LastDayOfMonth", "0 0 12 L * *"},
+		{"LeapDay", "0 0 0 29 2 *"},
+		{"LeapDayMonday", "0 0 0 29 2 MON"},
+	}
+	from := parseTime("2012-07-09 23:35:51")
+	for _, bm := range benchmarks {
+		cr, err := Parse(bm.expression)
+		if err != nil {
+			b.Fatalf("Unexpected error: %s", err)
+		}
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				cr.Next(from)
+			}
+		})
+	}
+}
+
+func TestNextDayPolicy(t *testing.T) {
+	tests := []struct {
+		now        string
+		expression string
+		policy     DayPolicy
+		expected   string
+		prev       string
+	}{
+		{"2012-07-09 23:35", "0 0 0 1 * MON", DayAnd, "2012-10-01 00:00", "2011-08-01 00:00"},
+		{"2012-07-09 23:35", "0 0 0 1 * MON", DayVixie, "2012-07-16 00:00", "2012-07-09 00:00"},
+		{"2012-07-25 23:35", "0 0 0 1 * MON", DayVixie, "2012-07-30 00:00", "2012-07-23 00:00"},
+		{"2012-07-31 23:35", "0 0 0 1 * MON", DayVixie, "2012-08-01 00:00", "2012-07-30 00:00"},
+		{"2012-07-09 23:35", "0 0 0 L * FRI#1", DayVixie, "2012-07-31 00:00", "2012-07-06 00:00"},
+		{"2012-07-09 23:35", "0 0 0 1 * *", DayVixie, "2012-08-01 00:00", "2012-07-01 00:00"},
+		{"2012-07-09 23:35", "0 0 0 ? * MON", DayQuartz, "2012-07-16 00:00", "2012-07-09 00:00"},
+		{"2012-07-09 23:35", "0 0 0 1 * ?", DayQuartz, "2012-08-01 00:00", "2012-07-01 00:00"},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression, WithDayPolicy(tt.policy))
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			now := parseTime(tt.now)
+			if expected, actual := parseTime(tt.expected), cr.Next(now); actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+			if expected, actual := parseTime(tt.prev), cr.Prev(now); actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+
+	for _, expression := range []string{"0 0 0 1 * MON", "0 0 0 L * FRI#1", "0 0 1-15 * 1-5"} {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Parse(expression, WithDayPolicy(DayQuartz)); err == nil {
+				t.Errorf("Expected error for %q", expression)
+			}
+		})
+	}
+}
+
+func TestNextMilliseconds(t *testing.T) {
+	tests := []struct {
+		now        string
+		expression string
+		expected   string
+		prev       string
+	}{
+		{"2012-07-09 14:45:00", "250,750 * * * * * *", "2012-07-09 14:45:00.25", "2012-07-09 14:44:59.75"},
+		{"2012-07-09 14:45:00.25", "250,750 * * * * * *", "2012-07-09 14:45:00.25", "2012-07-09 14:45:00.25"},
+		{"2012-07-09 14:45:00.2501", "250,750 * * * * * *", "2012-07-09 14:45:00.75", "2012-07-09 14:45:00.25"},
+		{"2012-07-09 14:45:00.8", "250,750 * * * * * *", "2012-07-09 14:45:01.25", "2012-07-09 14:45:00.75"},
+		{"2012-07-09 14:45:00.8", "*/100 * * * * * *", "2012-07-09 14:45:00.8", "2012-07-09 14:45:00.8"},
+		{"2012-07-09 14:45:00.8", "500 0 0 * * * *", "2012-07-09 15:00:00.5", "2012-07-09 14:00:00.5"},
+		{"2012-07-09 23:59:59.9", "0 0 0 0 * * *", "2012-07-10 00:00", "2012-07-09 00:00"},
+		{"2012-07-09 14:45:00.8", "0 0 0 0 1 1 * 2013", "2013-01-01 00:00", ""},
+		{"2012-07-09 14:45:00.8", "@hourly", "2012-07-09 15:00", "2012-07-09 14:00"},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression, WithMilliseconds())
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			now := parseTime(tt.now)
+			if expected, actual := parseTime(tt.expected), cr.Next(now); actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+			if expected, actual := parseTime(tt.prev), cr.Prev(now); actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+
+	for _, expression := range []string{"* * * * * *", "1000 * * * * * *", "0 0 0 * * * * * *", "0 * * * *"} {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Parse(expression, WithMilliseconds()); err == nil {
+				t.Errorf("Expected error for %q", expression)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		expression string
+		options    []Option
+		time       string
+		expected   bool
+	}{
+		{"0 0 9 * * MON-FRI", nil, "2012-07-09 09:00", true},
+		{"0 0 9 * * MON-FRI", nil, "2012-07-09 09:00:01", false},
+		{"0 0 9 * * MON-FRI", nil, "2012-07-09 09:00:00.5", false},
+		{"0 0 9 * * MON-FRI", nil, "2012-07-08 09:00", false},
+		{"0 0 12 LW * *", nil, "2012-06-29 12:00", true},
+		{"0 0 12 LW * *", nil, "2012-06-30 12:00", false},
+		{"0 0 0 1 1 * 2013", nil, "2012-01-01 00:00", false},
+		{"0 0 0 1 1 * 2013", nil, "2013-01-01 00:00", true},
+		{"250 0 0 9 * * *", []Option{WithMilliseconds()}, "2012-07-09 09:00:00.25", true},
+		{"250 0 0 9 * * *", []Option{WithMilliseconds()}, "2012-07-09 09:00", false},
+		{"CRON_TZ=Europe/Berlin 0 0 9 * * *", nil, "2012-07-09 07:00", true},
+		{"CRON_TZ=Europe/Berlin 0 0 9 * * *", nil, "2012-07-09 09:00", false},
+		// Berlin skips from 02:00 CET to 03:00 CEST on 2023-03-26 and repeats 02:00-03:00 on 2023-10-29
+		{"CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-03-26 01:00", true},
+		{"CRON_TZ=Europe/Berlin 0 30 2 * * *", []Option{WithGapPolicy(GapSkip)}, "2023-03-26 01:00", false},
+		{"CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-10-29 00:30", true},
+		{"CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-10-29 01:30", false},
+		{"CRON_TZ=Europe/Berlin 0 30 2 * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}, "2023-10-29 01:30", true},
+		{"0 0 9 * * *", []Option{WithCalendar(new(Calendar).AddDate(parseTime("2012-07-09 00:00")))}, "2012-07-09 09:00", false},
+		{"0 0 9 * * *", []Option{WithCalendar(new(Calendar).AddDate(parseTime("2012-07-09 00:00")))}, "2012-07-10 09:00", true},
+		{"@every 1h", nil, "2012-07-09 09:00", false},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression, tt.options...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := cr.Matches(parseTime(tt.time)); actual != tt.expected {
+				t.Errorf("Expected: %v Actual: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestField(t *testing.T) {
+	tests := []struct {
+		expression string
+		fieldType  CronFieldType
+		expected   []int
+	}{
+		{"0 */15 9-17 * * MON-FRI", Minute, []int{0, 15, 30, 45}},
+		{"0 */15 9-17 * * MON-FRI", Hour, []int{9, 10, 11, 12, 13, 14, 15, 16, 17}},
+		{"0 */15 9-17 * * MON-FRI", DOW, []int{1, 2, 3, 4, 5}},
+		{"0 0 0 * * SAT,7", DOW, []int{0, 6}},
+		{"0 0 0 1,L * *", DOM, []int{1}},
+		{"0 0 0 * JAN,JUL *", Month, []int{1, 7}},
+		{"0 0 0 * * * 2013,2011", Year, []int{2011, 2013}},
+		{"0 0 0 * * *", Year, nil},
+		{"0 0 0 * * *", Millisecond, nil},
+		{"@every 1h", Hour, nil},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := cr.Field(tt.fieldType); !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("Expected: %v Actual: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFieldNextOrSame(t *testing.T) {
+	tests := []struct {
+		expression string
+		fieldType  CronFieldType
+		from       string
+		expected   string
+	}{
+		{"0 */15 9-17 * * MON-FRI", Minute, "2012-07-09 14:45:10", "2012-07-09 14:45:10"},
+		{"0 */15 9-17 * * MON-FRI", Minute, "2012-07-09 14:46:10", "2012-07-09 15:00"},
+		{"0 */15 9-17 * * MON-FRI", Hour, "2012-07-09 18:30", "2012-07-10 09:00"},
+		{"0 */15 9-17 * * MON-FRI", DOW, "2012-07-14 12:00", "2012-07-16 00:00"},
+		{"0 0 0 L * *", DOM, "2012-02-03 12:00", "2012-02-29 00:00"},
+		{"0 0 0 * FEB *", Month, "2012-03-31 12:00", "2013-02-01 00:00"},
+		{"0 0 0 * * * 2013,2015", Year, "2014-07-09 12:00", "2015-01-01 00:00"},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if expected, actual := parseTime(tt.expected), cr.field(tt.fieldType).NextOrSame(parseTime(tt.from)); !actual.Equal(expected) {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+	cr, err := Parse("0 0 0 * * * 2013,2015")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if actual := cr.field(Year).NextOrSame(parseTime("2016-01-01 00:00")); !actual.IsZero() {
+		t.Errorf("Expected: %v Actual: %v", time.Time{}, actual)
+	}
+	if expected, actual := parseTime("2012-08-09 14:45"), cr.field(Month).Add(parseTime("2012-07-09 14:45"), 1); !actual.Equal(expected) {
+		t.Errorf("Expected: %v Actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,197 @@
+package cron
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CrontabEntry is a schedule of a crontab file and the command it runs.
+type CrontabEntry struct {
+	Schedule *CronExpression
+	Command  string
+	Line     int
+	Env      map[string]string // the variables assigned above the entry
+}
+
+// CrontabError is the error of a single line of a crontab file.
+type CrontabError struct {
+	Line int
+	Err  error
+}
+
+func (e *CrontabError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *CrontabError) Unwrap() error {
+	return e.Err
+}
+
+// CrontabErrors are the errors of all lines of a crontab file that failed to parse.
+type CrontabErrors []*CrontabError
+
+func (e CrontabErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// CrontabOption configures ParseCrontab.
+type CrontabOption func(*crontabOptions)
+
+type crontabOptions struct {
+	seconds bool
+	opts    []Option
+}
+
+// WithCrontabSeconds reads schedules of 6 or 7 fields, with seconds and years, e.g. the line
+// "0 0 9 * * MON-FRI report.sh". Without it every schedule has the standard 5 fields, so a command starting with a
+// number or a day like "5 things" isn't read as a field.
+func WithCrontabSeconds() CrontabOption {
+	return func(o *crontabOptions) {
+		o.seconds = true
+	}
+}
+
+// WithParseOptions passes the options to Parse for every schedule.
+func WithParseOptions(opts ...Option) CrontabOption {
+	return func(o *crontabOptions) {
+		o.opts = append(o.opts, opts...)
+	}
+}
+
+// ParseCrontab reads the entries of a crontab file. Blank lines and lines starting with # are skipped,
+// NAME=value lines assign environment variables to the entries below them, and CRON_TZ=<location> evaluates the
+// entries below it in the location. Every other line is a schedule followed by its command, the schedule is the
+// first 5 fields or a macro. With WithCrontabSeconds it's the longest prefix of 7, 6 or 5 fields Parse accepts, which
+// WithMilliseconds requires.
+//
+// The lines that fail to parse are reported together as CrontabErrors, the entries of the other lines are returned
+// along with it.
+func ParseCrontab(r io.Reader, crontabOpts ...CrontabOption) ([]CrontabEntry, error) {
+	o := crontabOptions{}
+	for _, opt := range crontabOpts {
+		opt(&o)
+	}
+	counts := []int{5}
+	if o.seconds {
+		counts = []int{7, 6, 5}
+	}
+	opts := o.opts
+	var entries []CrontabEntry
+	var errs CrontabErrors
+	env := map[string]string{}
+	var location *time.Location
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if name, value, ok := parseAssignment(trimmed); ok && !isZonePrefix(name, value) {
+			if name == "CRON_TZ" {
+				loc, err := time.LoadLocation(value)
+				if err != nil {
+					errs = append(errs, &CrontabError{Line: number, Err: err})
+					continue
+				}
+				location = loc
+			}
+			env = copyEnv(env)
+			env[name] = value
+			continue
+		}
+		lineOpts := opts
+		if location != nil {
+			lineOpts = append(append([]Option(nil), opts...), WithLocation(location))
+		}
+		schedule, command, err := parseCrontabLine(line, counts, lineOpts)
+		if err != nil {
+			errs = append(errs, &CrontabError{Line: number, Err: err})
+			continue
+		}
+		entries = append(entries, CrontabEntry{Schedule: schedule, Command: command, Line: number, Env: env})
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, err
+	}
+	if len(errs) > 0 {
+		return entries, errs
+	}
+	return entries, nil
+}
+
+// parseAssignment parses NAME=value and NAME = "value" lines.
+func parseAssignment(line string) (name, value string, ok bool) {
+	name, value, ok = strings.Cut(line, "=")
+	if !ok {
+		return "", "", false
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", "", false
+	}
+	for i, r := range name {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return "", "", false
+		}
+	}
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return name, value, true
+}
+
+// isZonePrefix reports whether the assignment is a CRON_TZ= or TZ= prefix of a schedule.
+func isZonePrefix(name, value string) bool {
+	return (name == "CRON_TZ" || name == "TZ") && strings.IndexFunc(value, unicode.IsSpace) != -1
+}
+
+// parseCrontabLine splits the line into the schedule of the longest of the field counts Parse accepts and the command.
+func parseCrontabLine(line string, counts []int, opts []Option) (*CronExpression, string, error) {
+	tokens := fields(token{value: line})
+	prefix := 0
+	if strings.HasPrefix(tokens[0].value, "CRON_TZ=") || strings.HasPrefix(tokens[0].value, "TZ=") {
+		prefix = 1
+	}
+	if len(tokens) > prefix && strings.HasPrefix(tokens[prefix].value, "@") {
+		counts = []int{1}
+		if strings.EqualFold(tokens[prefix].value, everyMacro) {
+			counts = []int{2}
+		}
+	}
+	var err error
+	for _, count := range counts {
+		count += prefix
+		if len(tokens) <= count {
+			continue
+		}
+		last := tokens[count-1]
+		var schedule *CronExpression
+		schedule, err = Parse(line[:last.offset+len(last.value)], opts...)
+		if err == nil {
+			return schedule, strings.TrimSpace(line[tokens[count].offset:]), nil
+		}
+	}
+	if err == nil {
+		return nil, "", fmt.Errorf("missing command")
+	}
+	// the error of the standard five-field format, or of the macro
+	return nil, "", err
+}
+
+func copyEnv(env map[string]string) map[string]string {
+	copied := make(map[string]string, len(env)+1)
+	for name, value := range env {
+		copied[name] = value
+	}
+	return copied
+}
@@ -0,0 +1,136 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseCrontab(t *testing.T) {
+	crontab := strings.Join([]string{
+		"# m h dom mon dow command",
+		"",
+		"SHELL=/bin/bash",
+		`MAILTO = "ops@example.com"`,
+		"*/5 * * * * /usr/bin/backup --incremental   --quiet",
+		"  0 9 * * MON-FRI report.sh",
+		"0 0 1 1 * happy-new-year.sh",
+		"@daily rotate-logs",
+		"@every 90s heartbeat",
+		"CRON_TZ=Europe/Berlin",
+		"0 9 * * * berlin.sh",
+		"TZ=America/New_York 0 9 * * * new-york.sh",
+		"PATH=/usr/local/bin:/usr/bin",
+		"\t# indented comment",
+		"30 2 * * SUN maintenance.sh\r",
+	}, "\n")
+	entries, err := ParseCrontab(strings.NewReader(crontab))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	tests := []struct {
+		schedule string
+		command  string
+		line     int
+		env      string
+	}{
+		{"0 */5 * * * *", "/usr/bin/backup --incremental   --quiet", 5, "ops@example.com"},
+		{"0 0 9 * * MON-FRI", "report.sh", 6, "ops@example.com"},
+		{"0 0 0 1 JAN *", "happy-new-year.sh", 7, "ops@example.com"},
+		{"0 0 0 * * *", "rotate-logs", 8, "ops@example.com"},
+		{"@every 1m30s", "heartbeat", 9, "ops@example.com"},
+		{"CRON_TZ=Europe/Berlin 0 0 9 * * *", "berlin.sh", 11, "ops@example.com"},
+		{"CRON_TZ=America/New_York 0 0 9 * * *", "new-york.sh", 12, "ops@example.com"},
+		{"CRON_TZ=Europe/Berlin 0 30 2 * * SUN", "maintenance.sh", 15, "ops@example.com"},
+	}
+	if len(entries) != len(tests) {
+		t.Fatalf("Expected: %d entries Actual: %d", len(tests), len(entries))
+	}
+	for i, tt := range tests {
+		entry := entries[i]
+		if actual := entry.Schedule.String(); actual != tt.schedule {
+			t.Errorf("Expected: %q Actual: %q", tt.schedule, actual)
+		}
+		if entry.Command != tt.command {
+			t.Errorf("Expected: %q Actual: %q", tt.command, entry.Command)
+		}
+		if entry.Line != tt.line {
+			t.Errorf("Expected: %d Actual: %d", tt.line, entry.Line)
+		}
+		if actual := entry.Env["MAILTO"]; actual != tt.env {
+			t.Errorf("Expected: %q Actual: %q", tt.env, actual)
+		}
+	}
+	if _, ok := entries[6].Env["PATH"]; ok {
+		t.Errorf("Unexpected PATH before its assignment")
+	}
+	if actual := entries[7].Env["PATH"]; actual != "/usr/local/bin:/usr/bin" {
+		t.Errorf("Expected: %q Actual: %q", "/usr/local/bin:/usr/bin", actual)
+	}
+}
+
+func TestParseCrontabSeconds(t *testing.T) {
+	tests := []struct {
+		line     string
+		opts     []CrontabOption
+		schedule string
+		command  string
+	}{
+		{"0 0 * * * 5 things", nil, "0 0 0 * * *", "5 things"},
+		{"30 0 9 * * MON-FRI report.sh", nil, "0 30 0 9 * *", "MON-FRI report.sh"},
+		{"0 0 * * * 5 things", []CrontabOption{WithCrontabSeconds()}, "0 0 * * * FRI", "things"},
+		{"30 0 9 * * MON-FRI report.sh", []CrontabOption{WithCrontabSeconds()}, "30 0 9 * * MON-FRI", "report.sh"},
+		{"0 0 0 1 1 * 2030 happy-new-year.sh", []CrontabOption{WithCrontabSeconds()}, "0 0 0 1 JAN * 2030", "happy-new-year.sh"},
+		{"250 0 0 9 * * MON-FRI report.sh", []CrontabOption{WithCrontabSeconds(), WithParseOptions(WithMilliseconds())}, "250 0 0 9 * * MON-FRI", "report.sh"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.line, len(tt.opts)), func(t *testing.T) {
+			entries, err := ParseCrontab(strings.NewReader(tt.line), tt.opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := entries[0].Schedule.String(); actual != tt.schedule {
+				t.Errorf("Expected: %q Actual: %q", tt.schedule, actual)
+			}
+			if entries[0].Command != tt.command {
+				t.Errorf("Expected: %q Actual: %q", tt.command, entries[0].Command)
+			}
+		})
+	}
+	if _, err := ParseCrontab(strings.NewReader("0 0 0 1 1 * 2030 happy-new-year.sh")); err == nil {
+		t.Errorf("Expected error for a 7-field line without WithCrontabSeconds")
+	}
+}
+
+func TestParseCrontabErrors(t *testing.T) {
+	crontab := strings.Join([]string{
+		"0 25 * * * too-late.sh",
+		"*/5 * * * * ok.sh",
+		"0 9 * * *",
+		"@fortnightly never.sh",
+		"CRON_TZ=Mars/Olympus",
+		"0 9 * * MON#6 sixth-monday.sh",
+	}, "\n")
+	entries, err := ParseCrontab(strings.NewReader(crontab))
+	if len(entries) != 1 || entries[0].Line != 2 {
+		t.Errorf("Expected: the entry of line 2 Actual: %v", entries)
+	}
+	var errs CrontabErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected CrontabErrors Actual: %v", err)
+	}
+	expected := []int{1, 3, 4, 5, 6}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected: %d errors Actual: %v", len(expected), errs)
+	}
+	for i, line := range expected {
+		if errs[i].Line != line {
+			t.Errorf("Expected: %d Actual: %d", line, errs[i].Line)
+		}
+	}
+	var parseErr *ParseError
+	if !errors.As(errs[0], &parseErr) || parseErr.Field != Hour || parseErr.Offset != 2 {
+		t.Errorf("Expected: hours error at offset 2 Actual: %v", errs[0])
+	}
+}
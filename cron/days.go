@@ -0,0 +1,190 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+type dayRuleKind int
+
+const (
+	lastDayOfMonth       dayRuleKind = 1 + iota // L, L-n
+	lastWeekdayOfMonth                          // LW
+	nearestWeekday                              // nW
+	lastDayOfWeekInMonth                        // dL
+	nthDayOfWeekInMonth                         // d#n
+)
+
+// dayRule is a day of month / day of week token that can't be expressed as a plain bit in CronField.bits,
+// because the matching day depends on the month it's evaluated in.
+type dayRule struct {
+	kind    dayRuleKind
+	day     int // day of month for nW, offset for L-n
+	weekday time.Weekday
+	nth     int
+}
+
+func (r dayRule) matches(date time.Time) bool {
+	day := date.Day()
+	last := daysIn(date)
+	switch r.kind {
+	case lastDayOfMonth:
+		return day == last-r.day
+	case lastWeekdayOfMonth:
+		return day == weekdayBefore(date, last)
+	case nearestWeekday:
+		if r.day > last {
+			return false
+		}
+		return day == weekdayNearest(date, r.day, last)
+	case lastDayOfWeekInMonth:
+		lastWeekday := weekdayOf(date, last)
+		return day == last-int(lastWeekday-r.weekday+7)%7
+	case nthDayOfWeekInMonth:
+		first := 1 + int(r.weekday-weekdayOf(date, 1)+7)%7
+		return day == first+7*(r.nth-1)
+	}
+	return false
+}
+
+// daysIn returns the number of days in the month of the given date.
+func daysIn(date time.Time) int {
+	return time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func weekdayOf(date time.Time, day int) time.Weekday {
+	return time.Date(date.Year(), date.Month(), day, 0, 0, 0, 0, time.UTC).Weekday()
+}
+
+// weekdayBefore returns the given day if it is a weekday, otherwise the Friday before it.
+func weekdayBefore(date time.Time, day int) int {
+	switch weekdayOf(date, day) {
+	case time.Saturday:
+		return day - 1
+	case time.Sunday:
+		return day - 2
+	}
+	return day
+}
+
+// weekdayNearest returns the weekday nearest to the given day without leaving the month.
+func weekdayNearest(date time.Time, day int, last int) int {
+	switch weekdayOf(date, day) {
+	case time.Saturday:
+		if day == 1 {
+			return 3
+		}
+		return day - 1
+	case time.Sunday:
+		if day == last {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+// parseDayRule parses L, L-n, LW and nW tokens of the day of month field and dL, DDDL, d#n and DDD#n tokens
+// of the day of week field. ok is false when the token is not a day rule and should be parsed as a range.
+func parseDayRule(part token, fieldType CronFieldType) (rule *dayRule, ok bool, err error) {
+	switch fieldType {
+	case DOM:
+		return parseDayOfMonthRule(part)
+	case DOW:
+		return parseDayOfWeekRule(part)
+	}
+	return nil, false, nil
+}
+
+func parseDayOfMonthRule(part token) (*dayRule, bool, error) {
+	value := strings.ToUpper(part.value)
+	switch {
+	case value == "L":
+		return &dayRule{kind: lastDayOfMonth}, true, nil
+	case value == "LW":
+		return &dayRule{kind: lastWeekdayOfMonth}, true, nil
+	case strings.HasPrefix(value, "L-"):
+		offsetToken := token{value: value[2:], offset: part.offset + 2}
+		offset, err := strconv.Atoi(offsetToken.value)
+		if err != nil {
+			return nil, true, newParseError(DOM, offsetToken, ReasonInvalidValue)
+		}
+		if offset < 0 || offset > 30 {
+			return nil, true, newParseError(DOM, offsetToken, ReasonOutOfRange)
+		}
+		return &dayRule{kind: lastDayOfMonth, day: offset}, true, nil
+	case strings.HasSuffix(value, "W"):
+		day, err := parseValue(token{value: value[:len(value)-1], offset: part.offset}, DOM)
+		if err != nil {
+			return nil, true, err
+		}
+		return &dayRule{kind: nearestWeekday, day: day}, true, nil
+	}
+	return nil, false, nil
+}
+
+func parseDayOfWeekRule(part token) (*dayRule, bool, error) {
+	value := strings.ToUpper(part.value)
+	if hash := strings.Index(value, "#"); hash != -1 {
+		weekday, err := parseWeekday(token{value: value[:hash], offset: part.offset})
+		if err != nil {
+			return nil, true, err
+		}
+		nthToken := token{value: value[hash+1:], offset: part.offset + hash + 1}
+		nth, err := strconv.Atoi(nthToken.value)
+		if err != nil {
+			return nil, true, newParseError(DOW, nthToken, ReasonInvalidValue)
+		}
+		if nth < 1 || nth > 5 {
+			return nil, true, newParseError(DOW, nthToken, ReasonOutOfRange)
+		}
+		return &dayRule{kind: nthDayOfWeekInMonth, weekday: weekday, nth: nth}, true, nil
+	}
+	if len(value) > 1 && strings.HasSuffix(value, "L") {
+		weekday, err := parseWeekday(token{value: value[:len(value)-1], offset: part.offset})
+		if err != nil {
+			return nil, true, err
+		}
+		return &dayRule{kind: lastDayOfWeekInMonth, weekday: weekday}, true, nil
+	}
+	return nil, false, nil
+}
+
+func parseWeekday(t token) (time.Weekday, error) {
+	v, err := parseValue(t, DOW)
+	if err != nil {
+		return 0, err
+	}
+	return time.Weekday(v % 7), nil
+}
+
+func (cr *CronField) isDayField() bool {
+	return cr.fieldType == DOM || cr.fieldType == DOW
+}
+
+// matchesDays reports whether the date matches the day of month and day of week fields under the day policy.
+func (cr *CronExpression) matchesDays(date time.Time) bool {
+	dom, dow := cr.field(DOM), cr.field(DOW)
+	if cr.eitherDay() {
+		return dom.matchesDay(date) || dow.matchesDay(date)
+	}
+	return dom.matchesDay(date) && dow.matchesDay(date)
+}
+
+// eitherDay reports whether a date matching one of the day fields is enough.
+func (cr *CronExpression) eitherDay() bool {
+	return cr.dayPolicy == DayVixie && !cr.field(DOM).isFull() && !cr.field(DOW).isFull()
+}
+
+func (cr *CronField) matchesDay(date time.Time) bool {
+	if cr.GetBit(cr.getPartOfTime(date)) == 1 {
+		return true
+	}
+	for _, rule := range cr.rules {
+		if rule.matches(date) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,332 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Locale holds the words and phrases a description is assembled from, the verbs are filled in by DescribeIn.
+type Locale struct {
+	Months   [12]string
+	Weekdays [7]string // starting with Sunday
+	Ordinals [5]string // first to fifth
+
+	And     string // joins the last two items of a list
+	Or      string // joins the days of month and the days of week under DayVixie
+	Through string // joins the ends of a range, e.g. "%s through %s"
+
+	At                 string // fixed times of day, e.g. "at %s"
+	EverySecond        string
+	EveryNSeconds      string
+	AtSecond           string
+	AtSeconds          string
+	EveryMinute        string
+	EveryNMinutes      string
+	AtMinute           string
+	AtMinutes          string
+	EveryNHours        string
+	AtHour             string
+	AtHours            string
+	EveryMillisecond   string
+	EveryNMilliseconds string
+	AtMillisecond      string
+	AtMilliseconds     string
+
+	DayOfMonth         string // single day of month item, e.g. "day %s"
+	DaysOfMonth        string
+	LastDayOfMonth     string
+	DaysBeforeLastDay  string
+	LastWeekdayOfMonth string
+	NearestWeekday     string
+	OnDaysOfMonth      string // wraps the day of month items, e.g. "on %s of the month"
+
+	EveryWeekday  string
+	LastDayOfWeek string // e.g. "the last %s of the month"
+	NthDayOfWeek  string // e.g. "the %s %s of the month"
+	OnDaysOfWeek  string
+
+	InMonths string
+	InYears  string
+	Every    string // @every expressions
+}
+
+// English is the default locale of Describe.
+var English = Locale{
+	Months:   [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+	Weekdays: [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	Ordinals: [5]string{"first", "second", "third", "fourth", "fifth"},
+
+	And:     "and",
+	Or:      "or",
+	Through: "%s through %s",
+
+	At:                 "at %s",
+	EverySecond:        "every second",
+	EveryNSeconds:      "every %d seconds",
+	AtSecond:           "at second %s",
+	AtSeconds:          "at seconds %s",
+	EveryMinute:        "every minute",
+	EveryNMinutes:      "every %d minutes",
+	AtMinute:           "at minute %s",
+	AtMinutes:          "at minutes %s",
+	EveryNHours:        "every %d hours",
+	AtHour:             "during hour %s",
+	AtHours:            "during hours %s",
+	EveryMillisecond:   "every millisecond",
+	EveryNMilliseconds: "every %d milliseconds",
+	AtMillisecond:      "at millisecond %s",
+	AtMilliseconds:     "at milliseconds %s",
+
+	DayOfMonth:         "day %s",
+	DaysOfMonth:        "days %s",
+	LastDayOfMonth:     "the last day",
+	DaysBeforeLastDay:  "%d days before the last day",
+	LastWeekdayOfMonth: "the last weekday",
+	NearestWeekday:     "the weekday nearest day %d",
+	OnDaysOfMonth:      "on %s of the month",
+
+	EveryWeekday:  "on every weekday",
+	LastDayOfWeek: "the last %s of the month",
+	NthDayOfWeek:  "the %s %s of the month",
+	OnDaysOfWeek:  "on %s",
+
+	InMonths: "in %s",
+	InYears:  "in %s",
+	Every:    "every %s",
+}
+
+// Describe returns an English description of the expression, e.g. "At 09:30 on every weekday in January and March".
+func (cr *CronExpression) Describe() string {
+	return cr.DescribeIn(English)
+}
+
+// DescribeIn returns a description of the expression in the given locale.
+func (cr *CronExpression) DescribeIn(locale Locale) string {
+	if cr.isZero() {
+		return ""
+	}
+	if cr.interval > 0 {
+		return capitalize(fmt.Sprintf(locale.Every, cr.interval))
+	}
+	d := describer{locale: locale}
+	parts := d.describeTime(cr.field(Hour), cr.field(Minute), cr.field(Second))
+	if millis := cr.field(Millisecond); millis != nil && !(len(millis.list) == 1 && millis.list[0] == 0) {
+		millisPart := d.locale.EveryMillisecond
+		if !millis.isFull() {
+			millisPart = d.describeUnit(millis, d.locale.EveryNMilliseconds, d.locale.AtMillisecond, d.locale.AtMilliseconds)
+		}
+		if len(parts) > 0 {
+			millisPart += ","
+		}
+		parts = append([]string{millisPart}, parts...)
+	}
+	if dom := cr.field(DOM); !dom.isFull() {
+		parts = append(parts, d.describeDaysOfMonth(dom))
+	}
+	if dow := cr.field(DOW); !dow.isFull() {
+		if cr.eitherDay() {
+			parts = append(parts, locale.Or)
+		}
+		parts = append(parts, d.describeDaysOfWeek(dow))
+	}
+	if months := cr.field(Month); !months.isFull() {
+		parts = append(parts, fmt.Sprintf(locale.InMonths, d.list(months.values(), d.month)))
+	}
+	if years := cr.field(Year); years != nil {
+		parts = append(parts, fmt.Sprintf(locale.InYears, d.list(years.values(), strconv.Itoa)))
+	}
+	return capitalize(strings.Join(parts, " "))
+}
+
+type describer struct {
+	locale Locale
+}
+
+func (d *describer) describeTime(hours, minutes, seconds *CronField) []string {
+	h, m, s := hours.values(), minutes.values(), seconds.values()
+	if len(m) == 1 && len(s) == 1 && len(h) <= 4 && !hours.isFull() && !isStep(h, hours.fieldRange) {
+		times := make([]string, 0, len(h))
+		for _, hour := range h {
+			times = append(times, clock(hour, m[0], s[0]))
+		}
+		return []string{fmt.Sprintf(d.locale.At, d.join(times))}
+	}
+
+	var parts []string
+	switch {
+	case seconds.isFull():
+		parts = append(parts, d.locale.EverySecond)
+	case len(s) == 1 && s[0] == 0:
+	default:
+		parts = append(parts, d.describeUnit(seconds, d.locale.EveryNSeconds, d.locale.AtSecond, d.locale.AtSeconds))
+	}
+	switch {
+	case minutes.isFull():
+		if !seconds.isFull() {
+			parts = append(parts, d.locale.EveryMinute)
+		}
+	default:
+		parts = append(parts, d.describeUnit(minutes, d.locale.EveryNMinutes, d.locale.AtMinute, d.locale.AtMinutes))
+	}
+	if !hours.isFull() {
+		parts = append(parts, d.describeUnit(hours, d.locale.EveryNHours, d.locale.AtHour, d.locale.AtHours))
+	}
+	for i := 1; i < len(parts); i++ {
+		parts[i-1] += ","
+	}
+	return parts
+}
+
+func (d *describer) describeUnit(field *CronField, everyN, single, plural string) string {
+	values := field.values()
+	if isStep(values, field.fieldRange) {
+		return fmt.Sprintf(everyN, values[1]-values[0])
+	}
+	if len(values) == 1 {
+		return fmt.Sprintf(single, strconv.Itoa(values[0]))
+	}
+	return fmt.Sprintf(plural, d.list(values, strconv.Itoa))
+}
+
+func (d *describer) describeDaysOfMonth(dom *CronField) string {
+	var items []string
+	if days := dom.values(); len(days) == 1 {
+		items = append(items, fmt.Sprintf(d.locale.DayOfMonth, strconv.Itoa(days[0])))
+	} else if len(days) > 1 {
+		items = append(items, fmt.Sprintf(d.locale.DaysOfMonth, d.list(days, strconv.Itoa)))
+	}
+	for _, rule := range dom.rules {
+		switch {
+		case rule.kind == lastDayOfMonth && rule.day == 0:
+			items = append(items, d.locale.LastDayOfMonth)
+		case rule.kind == lastDayOfMonth:
+			items = append(items, fmt.Sprintf(d.locale.DaysBeforeLastDay, rule.day))
+		case rule.kind == lastWeekdayOfMonth:
+			items = append(items, d.locale.LastWeekdayOfMonth)
+		case rule.kind == nearestWeekday:
+			items = append(items, fmt.Sprintf(d.locale.NearestWeekday, rule.day))
+		}
+	}
+	return fmt.Sprintf(d.locale.OnDaysOfMonth, d.join(items))
+}
+
+func (d *describer) describeDaysOfWeek(dow *CronField) string {
+	days := dow.values()
+	if len(dow.rules) == 0 && len(days) == 5 && days[0] == 1 && days[4] == 5 {
+		return d.locale.EveryWeekday
+	}
+	var items []string
+	if len(days) > 0 {
+		items = append(items, d.list(days, d.weekday))
+	}
+	for _, rule := range dow.rules {
+		switch rule.kind {
+		case lastDayOfWeekInMonth:
+			items = append(items, fmt.Sprintf(d.locale.LastDayOfWeek, d.weekday(int(rule.weekday))))
+		case nthDayOfWeekInMonth:
+			items = append(items, fmt.Sprintf(d.locale.NthDayOfWeek, d.locale.Ordinals[rule.nth-1], d.weekday(int(rule.weekday))))
+		}
+	}
+	return fmt.Sprintf(d.locale.OnDaysOfWeek, d.join(items))
+}
+
+// list joins the values, collapsing runs of three or more consecutive values into ranges.
+func (d *describer) list(values []int, name func(int) string) string {
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			items = append(items, fmt.Sprintf(d.locale.Through, name(values[i]), name(values[j])))
+			i = j + 1
+			continue
+		}
+		items = append(items, name(values[i]))
+		i++
+	}
+	return d.join(items)
+}
+
+func (d *describer) join(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " " + d.locale.And + " " + items[len(items)-1]
+}
+
+func (d *describer) month(month int) string {
+	return d.locale.Months[month-1]
+}
+
+func (d *describer) weekday(weekday int) string {
+	return d.locale.Weekdays[weekday]
+}
+
+// isStep reports whether the values are a whole range walked with a step greater than one, i.e. */n.
+func isStep(values []int, fr FieldRange) bool {
+	if len(values) < 2 || values[0] != fr.min {
+		return false
+	}
+	step := values[1] - values[0]
+	if step < 2 {
+		return false
+	}
+	for i := 1; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return false
+		}
+	}
+	return values[len(values)-1]+step > fr.max
+}
+
+func clock(hour, minute, second int) string {
+	if second != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", hour, minute, second)
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute)
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// values returns the set values of the field in ascending order.
+func (cr *CronField) values() []int {
+	if cr.isListField() {
+		return append([]int(nil), cr.list...)
+	}
+	var values []int
+	for i := cr.fieldRange.min; i <= cr.fieldRange.max; i++ {
+		if cr.GetBit(i) == 1 {
+			values = append(values, i)
+		}
+	}
+	return values
+}
+
+// isFull reports whether every value of the field is set and there are no day rules.
+func (cr *CronField) isFull() bool {
+	if len(cr.rules) > 0 {
+		return false
+	}
+	if cr.isListField() {
+		return len(cr.list) == cr.fieldRange.max-cr.fieldRange.min+1
+	}
+	max := cr.fieldRange.max
+	if cr.fieldType == DOW {
+		// Sunday is folded into 0
+		max = 6
+	}
+	for i := cr.fieldRange.min; i <= max; i++ {
+		if cr.GetBit(i) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,96 @@
+package cron
+
+import (
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"0 30 9 * 1,3 MON-FRI", "At 09:30 on every weekday in January and March"},
+		{"* * * * * *", "Every second"},
+		{"0 * * * * *", "Every minute"},
+		{"0 */15 * * * *", "Every 15 minutes"},
+		{"0 */15 9-17 * * *", "Every 15 minutes, during hours 9 through 17"},
+		{"30 */15 * * * *", "At second 30, every 15 minutes"},
+		{"* 0 * * * *", "Every second, at minute 0"},
+		{"0 0 */2 * * *", "At minute 0, every 2 hours"},
+		{"0 5,10,20 * * * *", "At minutes 5, 10 and 20"},
+		{"15 30 9,17 * * *", "At 09:30:15 and 17:30:15"},
+		{"0 0 12 L * *", "At 12:00 on the last day of the month"},
+		{"0 0 12 1,15,L-2 * *", "At 12:00 on days 1 and 15 and 2 days before the last day of the month"},
+		{"0 0 12 LW * *", "At 12:00 on the last weekday of the month"},
+		{"0 0 12 15W * *", "At 12:00 on the weekday nearest day 15 of the month"},
+		{"0 0 9 * * MON#2", "At 09:00 on the second Monday of the month"},
+		{"0 0 9 * * 5L", "At 09:00 on the last Friday of the month"},
+		{"0 0 9 * * SAT,SUN", "At 09:00 on Sunday and Saturday"},
+		{"0 0 9 1 * MON", "At 09:00 on day 1 of the month on Monday"},
+		{"0 0 0 1 1-6 * 2024-2026", "At 00:00 on day 1 of the month in January through June in 2024 through 2026"},
+		{"@daily", "At 00:00"},
+		{"@every 90m", "Every 1h30m0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			cr, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := cr.Describe(); actual != tt.expected {
+				t.Errorf("Expected: %q Actual: %q", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDescribeIn(t *testing.T) {
+	locale := English
+	locale.Months = [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"}
+	locale.At = "um %s"
+	locale.And = "und"
+	locale.InMonths = "im %s"
+
+	cr, err := Parse("0 30 9 * 1,3 *")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := "Um 09:30 im Januar und März"
+	if actual := cr.DescribeIn(locale); actual != expected {
+		t.Errorf("Expected: %q Actual: %q", expected, actual)
+	}
+}
+
+func TestDescribeDayVixie(t *testing.T) {
+	cr, err := Parse("0 0 0 1 * MON", WithDayPolicy(DayVixie))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := "At 00:00 on day 1 of the month or on Monday"
+	if actual := cr.Describe(); actual != expected {
+		t.Errorf("Expected: %q Actual: %q", expected, actual)
+	}
+}
+
+func TestDescribeMilliseconds(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"250,750 * * * * * *", "At milliseconds 250 and 750, every second"},
+		{"*/100 0 * * * * *", "Every 100 milliseconds, every minute"},
+		{"* * * * * * *", "Every millisecond, every second"},
+		{"0 0 30 9 * * *", "At 09:30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			cr, err := Parse(tt.expression, WithMilliseconds())
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := cr.Describe(); actual != tt.expected {
+				t.Errorf("Expected: %q Actual: %q", tt.expected, actual)
+			}
+		})
+	}
+}
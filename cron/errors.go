@@ -0,0 +1,72 @@
+package cron
+
+import "fmt"
+
+// Reason is the machine-readable cause of a ParseError.
+type Reason string
+
+const (
+	ReasonEmpty           Reason = "empty"
+	ReasonFieldCount      Reason = "field_count"
+	ReasonInvalidValue    Reason = "invalid_value"
+	ReasonOutOfRange      Reason = "out_of_range"
+	ReasonInvalidRange    Reason = "invalid_range"
+	ReasonInvalidStep     Reason = "invalid_step"
+	ReasonUnknownMacro    Reason = "unknown_macro"
+	ReasonInvalidInterval Reason = "invalid_interval"
+	ReasonInvalidLocation Reason = "invalid_location"
+	ReasonMissingHashSeed Reason = "missing_hash_seed"
+	ReasonNeverFires      Reason = "never_fires"
+	ReasonDayConflict     Reason = "day_conflict"
+)
+
+var reasonMessages = map[Reason]string{
+	ReasonEmpty:           "expression string must not be empty",
+	ReasonFieldCount:      "cron expression must consist of 5, 6 or 7 fields, or 7 or 8 with milliseconds",
+	ReasonInvalidValue:    "invalid value",
+	ReasonOutOfRange:      "value out of range",
+	ReasonInvalidRange:    "range start is after range end",
+	ReasonInvalidStep:     "step must be a positive number",
+	ReasonUnknownMacro:    "unknown macro",
+	ReasonInvalidInterval: "invalid @every interval",
+	ReasonInvalidLocation: "failed to load location",
+	ReasonMissingHashSeed: "H requires a hash seed, see WithHashSeed",
+	ReasonNeverFires:      "no date matches the days of month, months, days of week and years",
+	ReasonDayConflict:     "days of month and days of week must not both be restricted, use ? in one of them",
+}
+
+// ParseError describes why and where Parse failed, use errors.As to get it from the returned error.
+type ParseError struct {
+	Expression string
+	Field      CronFieldType // zero if the error isn't related to a single field
+	Token      string
+	Offset     int // byte offset of Token in Expression
+	Reason     Reason
+	Err        error // underlying error, if any
+}
+
+func (e *ParseError) Error() string {
+	msg := reasonMessages[e.Reason]
+	switch {
+	case e.Token != "" && e.Expression != "":
+		msg = fmt.Sprintf("%s %q at offset %d", msg, e.Token, e.Offset)
+	case e.Token != "":
+		// built by a Builder, there's no expression to point into
+		msg = fmt.Sprintf("%s %q", msg, e.Token)
+	}
+	if e.Field != 0 {
+		msg = fmt.Sprintf("failed to parse %s: %s", e.Field, msg)
+	}
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %s", msg, e.Err)
+	}
+	return msg
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func newParseError(fieldType CronFieldType, t token, reason Reason) *ParseError {
+	return &ParseError{Field: fieldType, Token: t.value, Offset: t.offset, Reason: reason}
+}
@@ -0,0 +1,70 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		expression string
+		field      CronFieldType
+		token      string
+		offset     int
+		reason     Reason
+	}{
+		{"", 0, "", 0, ReasonEmpty},
+		{"   ", 0, "", 0, ReasonEmpty},
+		{"* * * *", 0, "", 7, ReasonFieldCount},
+		{"0 0 0 * * * * *", 0, "", 15, ReasonFieldCount},
+		{"x * * * * *", Second, "x", 0, ReasonInvalidValue},
+		{"0 75 * * * *", Minute, "75", 2, ReasonOutOfRange},
+		{"0 0 10-5 * * *", Hour, "10-5", 4, ReasonInvalidRange},
+		{"0 0 0 * * TUE-MON", DOW, "TUE-MON", 10, ReasonInvalidRange},
+		{"0 0 1-25 * * *", Hour, "25", 6, ReasonOutOfRange},
+		{"0 0 0 1,,2 * *", DOM, "", 8, ReasonInvalidValue},
+		{"0 */0 * * * *", Minute, "0", 4, ReasonInvalidStep},
+		{"0 5/x * * * *", Minute, "x", 4, ReasonInvalidStep},
+		{"0 0 0 * Foo *", Month, "Foo", 8, ReasonInvalidValue},
+		{"0 0 0 L-31 * *", DOM, "31", 8, ReasonOutOfRange},
+		{"0 0 0 32W * *", DOM, "32", 6, ReasonOutOfRange},
+		{"0 0 0 * * MON#6", DOW, "6", 14, ReasonOutOfRange},
+		{"0 0 0 * * 1,XYZ#1", DOW, "XYZ", 12, ReasonInvalidValue},
+		{"0 0 0 * * * 1969", Year, "1969", 12, ReasonOutOfRange},
+		{"? * * * * *", Second, "?", 0, ReasonInvalidValue},
+		{"  0 */x * * * *", Minute, "x", 6, ReasonInvalidStep},
+		{"@fortnightly", 0, "@fortnightly", 0, ReasonUnknownMacro},
+		{"@every 1x", 0, "1x", 7, ReasonInvalidInterval},
+		{"@every", 0, "@every", 0, ReasonInvalidInterval},
+		{"CRON_TZ=Mars/Olympus 0 0 0 * * *", 0, "CRON_TZ=Mars/Olympus", 0, ReasonInvalidLocation},
+		{"CRON_TZ=UTC 0 0 25 * * *", Hour, "25", 16, ReasonOutOfRange},
+		{"0 0 0 31 2 *", 0, "", 6, ReasonNeverFires},
+		{"0 0 30 FEB *", 0, "", 4, ReasonNeverFires},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := Parse(tt.expression)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected ParseError, actual: %v", err)
+			}
+			if parseErr.Expression != tt.expression || parseErr.Field != tt.field || parseErr.Token != tt.token ||
+				parseErr.Offset != tt.offset || parseErr.Reason != tt.reason {
+				t.Errorf("Unexpected error: %#v", parseErr)
+			}
+		})
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	_, err := Parse("0 75 * * * *")
+	expected := `failed to parse minutes: value out of range "75" at offset 2`
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected: %q Actual: %v", expected, err)
+	}
+
+	_, err = Parse("@every 1x")
+	if errors.Unwrap(err) == nil {
+		t.Errorf("Expected wrapped time.ParseDuration error, actual: %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package cron
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+var propertyExpressions = []string{
+	"* * * * * *",
+	"0 0 * * * *",
+	"*/7 */13 * * * *",
+	"0 15,45 9-17 * * MON-FRI",
+	"0 0 12 L * *",
+	"0 0 12 L-3 * *",
+	"0 0 12 LW * *",
+	"0 0 12 15W * *",
+	"0 0 9 * * 5L",
+	"0 0 9 * * MON#2",
+	"0 0 0 29 2 *",
+	"0 0 0 31 * *",
+	"0 0 0 13 * FRI",
+	"0 0 0 1 JAN * 2020-2030/5",
+	"0 30 2 * * SUN",
+	"*/15 9 * * 1-5",
+	"CRON_TZ=Europe/Berlin 0 30 2 * * *",
+	"CRON_TZ=Europe/Berlin 0 */20 1-3 * * SUN",
+	"CRON_TZ=America/New_York 0 0 1 L * *",
+	"@hourly",
+	"@weekly",
+}
+
+// propertyOpts make the fire times exactly the instants whose wall clock matches the fields, skipping the wall clock
+// times of a gap and firing twice at those of an overlap, so the oracle needs no daylight saving time rules.
+var propertyOpts = []Option{WithGapPolicy(GapSkip), WithOverlapPolicy(OverlapRunTwice)}
+
+// oracleMatches checks every field of t, independently of the search of Next and Prev.
+func oracleMatches(cr *CronExpression, t time.Time) bool {
+	if t.Nanosecond()%int(cr.precision()) != 0 {
+		return false
+	}
+	if cr.location != nil {
+		t = t.In(cr.location)
+	}
+	for i := range cr.fields {
+		field := &cr.fields[i]
+		if !field.isDayField() && !field.contains(field.getPartOfTime(t)) {
+			return false
+		}
+	}
+	dom, dow := oracleDay(cr.field(DOM), t), oracleDay(cr.field(DOW), t)
+	if cr.dayPolicy == DayVixie && !cr.field(DOM).isFull() && !cr.field(DOW).isFull() {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// oracleDay checks the day of t against a day field with plain date arithmetic rather than the day rules.
+func oracleDay(field *CronField, t time.Time) bool {
+	if field.contains(field.getPartOfTime(t)) {
+		return true
+	}
+	day, weekday := t.Day(), t.Weekday()
+	last := t.AddDate(0, 1, -day).Day()
+	isWeekday := func(d int) bool {
+		w := t.AddDate(0, 0, d-day).Weekday()
+		return w != time.Saturday && w != time.Sunday
+	}
+	for _, rule := range field.rules {
+		var matches bool
+		switch rule.kind {
+		case lastDayOfMonth:
+			matches = day == last-rule.day
+		case lastWeekdayOfMonth:
+			lastWeekday := last
+			for !isWeekday(lastWeekday) {
+				lastWeekday--
+			}
+			matches = day == lastWeekday
+		case nearestWeekday:
+			if rule.day > last {
+				break
+			}
+			// the closest weekday of the month, before the day at the same distance
+		nearest:
+			for distance := 0; distance <= 3; distance++ {
+				for _, d := range []int{rule.day - distance, rule.day + distance} {
+					if d >= 1 && d <= last && isWeekday(d) {
+						matches = day == d
+						break nearest
+					}
+				}
+			}
+		case lastDayOfWeekInMonth:
+			matches = weekday == rule.weekday && day > last-7
+		case nthDayOfWeekInMonth:
+			matches = weekday == rule.weekday && (day-1)/7+1 == rule.nth
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNext verifies Next and Prev from a UTC time against the oracle, visiting at most steps fire times of precision.
+func checkNext(t *testing.T, cr *CronExpression, from time.Time, steps int) {
+	precision := cr.precision()
+	next := cr.Next(from)
+	if !next.IsZero() {
+		if next.Before(from) {
+			t.Fatalf("%q: Next(%v) = %v is before it", cr, from, next)
+		}
+		if !oracleMatches(cr, next) {
+			t.Fatalf("%q: Next(%v) = %v doesn't match", cr, from, next)
+		}
+		if prev := cr.Prev(next); !prev.Equal(next) {
+			t.Fatalf("%q: Prev(%v) = %v", cr, next, prev)
+		}
+	}
+	// Next is monotonic, every time up to the next time has the same next time
+	if !next.IsZero() {
+		for _, c := range []time.Time{from.Add(next.Sub(from) / 2), next} {
+			if actual := cr.Next(c); !actual.Equal(next) {
+				t.Fatalf("%q: Next(%v) = %v, but Next(%v) = %v", cr, from, next, c, actual)
+			}
+		}
+	}
+	// nothing is skipped between from and the next time
+	start := from.Truncate(precision)
+	if start.Before(from) {
+		start = start.Add(precision)
+	}
+	for i, c := 0, start; i < steps && (next.IsZero() || c.Before(next)); i, c = i+1, c.Add(precision) {
+		if oracleMatches(cr, c) {
+			t.Fatalf("%q: Next(%v) = %v skips %v", cr, from, next, c)
+		}
+	}
+
+	prev := cr.Prev(from)
+	if !prev.IsZero() {
+		if prev.After(from) {
+			t.Fatalf("%q: Prev(%v) = %v is after it", cr, from, prev)
+		}
+		if !oracleMatches(cr, prev) {
+			t.Fatalf("%q: Prev(%v) = %v doesn't match", cr, from, prev)
+		}
+	}
+	for i, c := 0, from.Truncate(precision); i < steps && (prev.IsZero() || c.After(prev)); i, c = i+1, c.Add(-precision) {
+		if oracleMatches(cr, c) {
+			t.Fatalf("%q: Prev(%v) = %v skips %v", cr, from, prev, c)
+		}
+	}
+}
+
+func TestNextProperties(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	min, max := parseTime("1990-01-01 00:00").Unix(), parseTime("2090-01-01 00:00").Unix()
+	for _, expression := range propertyExpressions {
+		t.Run(expression, func(t *testing.T) {
+			cr, err := Parse(expression, propertyOpts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			for i := 0; i < 100; i++ {
+				from := time.Unix(min+random.Int63n(max-min), random.Int63n(int64(time.Second))).UTC()
+				checkNext(t, cr, from, 10000)
+			}
+			// right before the daylight saving time transitions of Europe/Berlin and America/New_York
+			for _, from := range []string{"2023-03-26 00:59:59", "2023-10-29 00:30", "2023-03-12 06:59:59", "2023-11-05 05:30"} {
+				checkNext(t, cr, parseTime(from), 10000)
+			}
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, expression := range propertyExpressions {
+		f.Add(expression, false)
+	}
+	f.Add("CRON_TZ=Europe/Berlin 0 0 9 * * *", false)
+	f.Add("H H(0-5) * * *", false)
+	f.Add("@every 1h30m", false)
+	f.Add("250,750 */10 * * * * *", true)
+	f.Fuzz(func(t *testing.T, expression string, milliseconds bool) {
+		var opts []Option
+		if milliseconds {
+			opts = append(opts, WithMilliseconds())
+		}
+		cr, err := Parse(expression, opts...)
+		if err != nil {
+			return
+		}
+		roundTrip, err := Parse(cr.String(), opts...)
+		if err != nil {
+			t.Fatalf("%q: String %q doesn't parse: %s", expression, cr, err)
+		}
+		if !roundTrip.Equal(cr) {
+			t.Fatalf("%q: String %q parses to a different expression", expression, cr)
+		}
+		from := parseTime("2012-07-09 14:45")
+		if next := cr.Next(from); !next.IsZero() && next.Before(from) {
+			t.Fatalf("%q: Next(%v) = %v is before it", expression, from, next)
+		}
+	})
+}
+
+func FuzzNext(f *testing.F) {
+	for i, expression := range propertyExpressions {
+		f.Add(expression, int64(i)*1e9+int64(i)*7919)
+	}
+	f.Add("0,500 * * * * * *", int64(1341845100123))
+	f.Fuzz(func(t *testing.T, expression string, millis int64) {
+		opts := append([]Option{WithMilliseconds()}, propertyOpts...)
+		cr, err := Parse(expression, opts...)
+		if err != nil {
+			opts = opts[1:]
+			if cr, err = Parse(expression, opts...); err != nil {
+				return
+			}
+		}
+		if cr.interval > 0 {
+			return
+		}
+		// 1970 to 2099 like the year field
+		const span = 130 * 365 * 24 * 60 * 60 * 1000
+		if millis %= span; millis < 0 {
+			millis += span
+		}
+		from := time.UnixMilli(millis).UTC()
+		checkNext(t, cr, from, 1000)
+		if cr.location != nil {
+			return
+		}
+		// the same fields in a location with daylight saving time, a CRON_TZ= prefix takes precedence
+		berlin, err := time.LoadLocation("Europe/Berlin")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if cr, err = Parse(expression, append(opts, WithLocation(berlin))...); err == nil {
+			checkNext(t, cr, from, 1000)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// spreadRanges narrows the range of H and R in the day fields, so it is valid in every month and Sunday isn't picked twice.
+var spreadRanges = map[CronFieldType]FieldRange{
+	DOM: {min: 1, max: 28},
+	DOW: {min: 0, max: 6},
+}
+
+// parseHash resolves Jenkins style H, H(a-b), H/n and H(a-b)/n tokens into values picked by the hash of the seed,
+// ok is false when the token is not a hash token.
+func parseHash(part token, fieldType CronFieldType, o *options) (values []int, ok bool, err error) {
+	if !strings.HasPrefix(part.value, "H") {
+		return nil, false, nil
+	}
+	r, rest, err := parseSpreadRange(part, fieldType)
+	if err != nil {
+		return nil, true, err
+	}
+	step := 0
+	if strings.HasPrefix(rest.value, "/") {
+		stepToken := token{value: rest.value[1:], offset: rest.offset + 1}
+		step, err = strconv.Atoi(stepToken.value)
+		if err != nil || step <= 0 {
+			return nil, true, newParseError(fieldType, stepToken, ReasonInvalidStep)
+		}
+	} else if rest.value != "" {
+		return nil, true, newParseError(fieldType, part, ReasonInvalidValue)
+	}
+	if o.hashSeed == "" {
+		return nil, true, newParseError(fieldType, part, ReasonMissingHashSeed)
+	}
+
+	h := hash(o.hashSeed, fieldType)
+	span := r.max - r.min + 1
+	if step == 0 {
+		return []int{r.min + int(h%uint32(span))}, true, nil
+	}
+	if step < span {
+		span = step
+	}
+	for value := r.min + int(h%uint32(span)); value <= r.max; value += step {
+		values = append(values, value)
+	}
+	return values, true, nil
+}
+
+// parseSpreadRange parses the optional (a-b) range following H or R, rest is the remainder of the token.
+func parseSpreadRange(part token, fieldType CronFieldType) (r FieldRange, rest token, err error) {
+	r, ok := spreadRanges[fieldType]
+	if !ok {
+		r = fieldRange[fieldType]
+	}
+	rest = token{value: part.value[1:], offset: part.offset + 1}
+	if !strings.HasPrefix(rest.value, "(") {
+		return r, rest, nil
+	}
+	end := strings.Index(rest.value, ")")
+	if end == -1 {
+		return r, rest, newParseError(fieldType, part, ReasonInvalidValue)
+	}
+	spreadRange, err := parseRange(token{value: rest.value[1:end], offset: rest.offset + 1}, fieldType)
+	if err != nil {
+		return r, rest, err
+	}
+	return *spreadRange, token{value: rest.value[end+1:], offset: rest.offset + end + 1}, nil
+}
+
+// hash mixes the field type into the seed, so the fields of an expression don't get the same offset.
+func hash(seed string, fieldType CronFieldType) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(seed))
+	_, _ = h.Write([]byte{byte(fieldType)})
+	return h.Sum32()
+}
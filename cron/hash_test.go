@@ -0,0 +1,111 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		expression string
+		fieldType  CronFieldType
+		min        int
+		max        int
+		step       int
+		count      int
+	}{
+		{"0 H * * * *", Minute, 0, 59, 0, 1},
+		{"H * * * * *", Second, 0, 59, 0, 1},
+		{"0 H(0-29) * * * *", Minute, 0, 29, 0, 1},
+		{"0 H/15 * * * *", Minute, 0, 59, 15, 4},
+		{"0 H(0-29)/10 * * * *", Minute, 0, 29, 10, 3},
+		{"0 0 H(9-17)/4 * * *", Hour, 9, 17, 4, 2},
+		{"0 0 0 H * *", DOM, 1, 28, 0, 1},
+		{"0 0 0 * * H", DOW, 0, 6, 0, 1},
+		{"0 0 0 * H *", Month, 1, 12, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				seed := fmt.Sprintf("job-%d", i)
+				cr, err := Parse(tt.expression, WithHashSeed(seed))
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				values := cr.field(tt.fieldType).values()
+				if len(values) < tt.count || len(values) > tt.count+1 {
+					t.Fatalf("Expected %d values Actual: %v", tt.count, values)
+				}
+				for j, value := range values {
+					if value < tt.min || value > tt.max {
+						t.Errorf("Value %d out of range %d-%d", value, tt.min, tt.max)
+					}
+					if j > 0 && value-values[j-1] != tt.step {
+						t.Errorf("Expected step %d Actual: %v", tt.step, values)
+					}
+				}
+				if tt.step > 0 && values[0]-tt.min >= tt.step {
+					t.Errorf("Expected first value within the first step Actual: %v", values)
+				}
+
+				again, err := Parse(tt.expression, WithHashSeed(seed))
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				if !cr.Equal(again) {
+					t.Errorf("Expected the same seed to resolve to the same values: %s %s", cr, again)
+				}
+			}
+		})
+	}
+}
+
+func TestHashSpread(t *testing.T) {
+	minutes := map[int]bool{}
+	for i := 0; i < 100; i++ {
+		cr, err := Parse("0 H * * * *", WithHashSeed(fmt.Sprintf("tenant-%d", i)))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		minutes[cr.field(Minute).values()[0]] = true
+	}
+	if len(minutes) < 30 {
+		t.Errorf("Expected seeds to spread over the hour, got %d distinct minutes", len(minutes))
+	}
+
+	cr, err := Parse("H H * * * *", WithHashSeed("job"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if text, _ := cr.MarshalText(); string(text) != cr.String() {
+		t.Errorf("Expected hashed expressions to marshal resolved, actual: %s", text)
+	}
+}
+
+func TestHashErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		options    []Option
+		token      string
+		reason     Reason
+	}{
+		{"0 H * * * *", nil, "H", ReasonMissingHashSeed},
+		{"0 H(0-29 * * * *", []Option{WithHashSeed("job")}, "H(0-29", ReasonInvalidValue},
+		{"0 H(0-75) * * * *", []Option{WithHashSeed("job")}, "75", ReasonOutOfRange},
+		{"0 H/0 * * * *", []Option{WithHashSeed("job")}, "0", ReasonInvalidStep},
+		{"0 Hx * * * *", []Option{WithHashSeed("job")}, "Hx", ReasonInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := Parse(tt.expression, tt.options...)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected ParseError, actual: %v", err)
+			}
+			if parseErr.Token != tt.token || parseErr.Reason != tt.reason {
+				t.Errorf("Unexpected error: %#v", parseErr)
+			}
+		})
+	}
+}
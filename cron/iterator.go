@@ -0,0 +1,104 @@
+package cron
+
+import "time"
+
+// Iterator walks over the fire times of an expression.
+type Iterator struct {
+	expression *CronExpression
+	base       time.Time // the next fire time without jitter, the search continues from it
+	next       time.Time
+	bound      time.Time
+	reverse    bool
+	done       bool
+}
+
+// Iter returns an iterator over the fire times at or after from, in chronological order.
+func (cr *CronExpression) Iter(from time.Time) *Iterator {
+	it := &Iterator{expression: cr}
+	it.advance(cr.next(from))
+	return it
+}
+
+// Between returns an iterator over the fire times within [start, end], most recent first.
+func (cr *CronExpression) Between(start, end time.Time) *Iterator {
+	it := &Iterator{expression: cr, bound: start, reverse: true}
+	it.advance(cr.Prev(end))
+	return it
+}
+
+// Next returns the next fire time, false once the iterator is exhausted.
+func (it *Iterator) Next() (time.Time, bool) {
+	if it.done {
+		return time.Time{}, false
+	}
+	current := it.next
+	if current.IsZero() || (it.reverse && current.Before(it.bound)) {
+		it.done = true
+		return time.Time{}, false
+	}
+	if it.reverse {
+		it.advance(it.expression.before(it.base))
+	} else {
+		it.advance(it.expression.after(it.base))
+	}
+	return current, true
+}
+
+// advance moves to the next fire time without jitter, only the returned times are jittered, like those of Next.
+func (it *Iterator) advance(base time.Time) {
+	it.base, it.next = base, base
+	if !it.reverse {
+		it.next = it.expression.addJitter(base)
+	}
+}
+
+// Take returns up to n next fire times.
+func (it *Iterator) Take(n int) []time.Time {
+	var result []time.Time
+	for len(result) < n {
+		next, ok := it.Next()
+		if !ok {
+			break
+		}
+		result = append(result, next)
+	}
+	return result
+}
+
+// Until returns the next fire times up to t, exclusive. The first fire time past t is left in the iterator.
+func (it *Iterator) Until(t time.Time) []time.Time {
+	var result []time.Time
+	for !it.next.IsZero() && it.isBefore(it.next, t) {
+		next, ok := it.Next()
+		if !ok {
+			break
+		}
+		result = append(result, next)
+	}
+	return result
+}
+
+// isBefore compares in the direction of the iteration.
+func (it *Iterator) isBefore(t, limit time.Time) bool {
+	if it.reverse {
+		return t.After(limit)
+	}
+	return t.Before(limit)
+}
+
+// after returns the first fire time strictly after t, without jitter.
+func (cr *CronExpression) after(t time.Time) time.Time {
+	if cr.interval > 0 {
+		return cr.next(t)
+	}
+	precision := cr.precision()
+	return cr.next(t.Truncate(precision).Add(precision))
+}
+
+// before returns the last fire time strictly before t.
+func (cr *CronExpression) before(t time.Time) time.Time {
+	if cr.interval > 0 {
+		return cr.Prev(t)
+	}
+	return cr.Prev(t.Add(-time.Nanosecond))
+}
@@ -0,0 +1,58 @@
+package cron
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIterTake(t *testing.T) {
+	tests := []struct {
+		from       string
+		expression string
+		n          int
+		expected   []string
+	}{
+		{"2012-07-09 14:45", "0 0/15 * * * *", 3, []string{"2012-07-09 14:45", "2012-07-09 15:00", "2012-07-09 15:15"}},
+		{"2012-07-09 14:45:30", "* * * * * *", 2, []string{"2012-07-09 14:45:30", "2012-07-09 14:45:31"}},
+		{"2012-01-01 00:00", "0 0 0 L * *", 3, []string{"2012-01-31 00:00", "2012-02-29 00:00", "2012-03-31 00:00"}},
+		{"2012-07-09 14:45", "@every 90m", 2, []string{"2012-07-09 16:15", "2012-07-09 17:45"}},
+		{"2012-07-09 14:45", "0 0 0 1 1 * 2013-2014", 5, []string{"2013-01-01 00:00", "2014-01-01 00:00"}},
+		{"2012-07-09 14:45", "0 0 * * * *", 0, nil},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			assertTimes(t, tt.expected, cr.Iter(parseTime(tt.from)).Take(tt.n))
+		})
+	}
+}
+
+func TestIterUntil(t *testing.T) {
+	cr, err := Parse("0 0 */6 * * *")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	it := cr.Iter(parseTime("2012-07-09 00:00"))
+	assertTimes(t, []string{"2012-07-09 00:00", "2012-07-09 06:00", "2012-07-09 12:00", "2012-07-09 18:00"}, it.Until(parseTime("2012-07-10 00:00")))
+	assertTimes(t, []string{"2012-07-10 00:00"}, it.Take(1))
+
+	reverse := cr.Between(parseTime("2012-07-01 00:00"), parseTime("2012-07-09 12:00"))
+	assertTimes(t, []string{"2012-07-09 12:00", "2012-07-09 06:00"}, reverse.Until(parseTime("2012-07-09 00:00")))
+	assertTimes(t, []string{"2012-07-09 00:00"}, reverse.Take(1))
+}
+
+func assertTimes(t *testing.T, expected []string, actual []time.Time) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected: %v Actual: %v", expected, actual)
+	}
+	for i, value := range expected {
+		if actual[i] != parseTime(value) {
+			t.Errorf("Expected: %v Actual: %v", value, actual[i])
+		}
+	}
+}
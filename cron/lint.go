@@ -0,0 +1,164 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Code is the machine-readable kind of a Warning.
+type Code string
+
+const (
+	CodeParseError         Code = "parse_error"
+	CodeWildcardBelowValue Code = "wildcard_below_value"
+	CodeUnevenStep         Code = "uneven_step"
+	CodeMissingDay         Code = "missing_day"
+)
+
+// Severity ranks a Warning.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "error"
+}
+
+// Warning is a suspicious part of an expression reported by Lint.
+type Warning struct {
+	Code     Code
+	Severity Severity
+	Field    CronFieldType // zero if the warning isn't related to a single field
+	Message  string
+}
+
+func (w Warning) String() string {
+	if w.Field != 0 {
+		return fmt.Sprintf("%s: %s: %s", w.Severity, w.Field, w.Message)
+	}
+	return fmt.Sprintf("%s: %s", w.Severity, w.Message)
+}
+
+// Lint reports legal but likely mistaken parts of an expression, like * 0 * * * * firing every second of the first
+// minute of every hour, steps that don't divide the field range like */7 minutes, and days of month that some of
+// the months don't have. An expression Parse rejects with the options is reported as a single SeverityError warning.
+func Lint(expression string, opts ...Option) []Warning {
+	cr, err := Parse(expression, opts...)
+	if err != nil {
+		return []Warning{{Code: CodeParseError, Severity: SeverityError, Message: err.Error()}}
+	}
+	if cr.interval > 0 {
+		return nil
+	}
+	var warnings []Warning
+	warnings = append(warnings, cr.lintWildcards()...)
+	warnings = append(warnings, cr.lintSteps()...)
+	warnings = append(warnings, cr.lintMissingDays()...)
+	return warnings
+}
+
+// lintWildcards reports a * right below a field with a single value, which fires every unit of that single value.
+func (cr *CronExpression) lintWildcards() []Warning {
+	var warnings []Warning
+	fieldTypes := []CronFieldType{Millisecond, Second, Minute, Hour}
+	for i, fieldType := range fieldTypes[:len(fieldTypes)-1] {
+		lower, higher := cr.field(fieldType), cr.field(fieldTypes[i+1])
+		if lower == nil || !lower.isFull() || higher.count() != 1 {
+			continue
+		}
+		warnings = append(warnings, Warning{
+			Code:     CodeWildcardBelowValue,
+			Severity: SeverityWarning,
+			Field:    fieldType,
+			Message: fmt.Sprintf("* fires at all %d %s of %s %d, use 0 to fire once",
+				lower.fieldRange.max-lower.fieldRange.min+1, fieldType, strings.TrimSuffix(higher.fieldType.String(), "s"), higher.values()[0]),
+		})
+	}
+	return warnings
+}
+
+// lintSteps reports steps through the end of a field range that don't divide it, so the gap from the last value to
+// the first one of the next hour, day, etc. is shorter than the step.
+func (cr *CronExpression) lintSteps() []Warning {
+	var warnings []Warning
+	for _, fieldType := range []CronFieldType{Millisecond, Second, Minute, Hour, Month, DOW} {
+		field := cr.field(fieldType)
+		if field == nil {
+			continue
+		}
+		values := field.values()
+		step, ok := arithmetic(values)
+		if !ok || len(values) < 3 {
+			// two values are as likely a list like 0,45 as a step
+			continue
+		}
+		min, max := field.fieldRange.min, field.fieldRange.max
+		if fieldType == DOW {
+			// Sunday is folded into 0
+			max = 6
+		}
+		first, last := values[0], values[len(values)-1]
+		if first-min >= step || last+step <= max {
+			// not a */n or a/n step through the whole range
+			continue
+		}
+		if wrap := max - last + 1 + first - min; wrap != step {
+			warnings = append(warnings, Warning{
+				Code:     CodeUnevenStep,
+				Severity: SeverityWarning,
+				Field:    fieldType,
+				Message: fmt.Sprintf("step %d doesn't divide the %d %s, %s is followed by %s after %d instead of %d",
+					step, max-min+1, fieldType, field.name(last), field.name(first), wrap, step),
+			})
+		}
+	}
+	return warnings
+}
+
+// lintMissingDays reports days of month that some of the months don't have, those months are skipped.
+func (cr *CronExpression) lintMissingDays() []Warning {
+	var warnings []Warning
+	dom, months := cr.field(DOM), cr.field(Month)
+	if dom.isFull() {
+		return nil
+	}
+	for _, day := range dom.values() {
+		if day < 29 {
+			continue
+		}
+		var missing []string
+		for _, month := range months.values() {
+			if day > daysInMonth[month-1] {
+				missing = append(missing, months.name(month))
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		warning := Warning{
+			Code:     CodeMissingDay,
+			Severity: SeverityWarning,
+			Field:    DOM,
+			Message:  fmt.Sprintf("day %d is skipped in %s", day, strings.Join(missing, ",")),
+		}
+		if day == 29 {
+			// only February lacks it, and only in common years
+			warning.Severity, warning.Message = SeverityInfo, "day 29 is skipped in FEB except in leap years"
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings
+}
+
+// daysInMonth are the days of the months of a common year.
+var daysInMonth = [12]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		expression string
+		opts       []Option
+		expected   []Warning
+	}{
+		{"0 */15 9-17 * * MON-FRI", nil, nil},
+		{"0 * 9-17 * * *", nil, nil},
+		{"@every 7m", nil, nil},
+		{"* 0 * * * *", nil, []Warning{
+			{CodeWildcardBelowValue, SeverityWarning, Second, "* fires at all 60 seconds of minute 0, use 0 to fire once"},
+		}},
+		{"* 9 * * *", nil, []Warning{
+			{CodeWildcardBelowValue, SeverityWarning, Minute, "* fires at all 60 minutes of hour 9, use 0 to fire once"},
+		}},
+		{"* 0 0 * * * *", []Option{WithMilliseconds()}, []Warning{
+			{CodeWildcardBelowValue, SeverityWarning, Millisecond, "* fires at all 1000 milliseconds of second 0, use 0 to fire once"},
+		}},
+		{"0 */7 * * * *", nil, []Warning{
+			{CodeUnevenStep, SeverityWarning, Minute, "step 7 doesn't divide the 60 minutes, 56 is followed by 0 after 4 instead of 7"},
+		}},
+		{"0 5/15 * * * *", nil, nil},
+		{"0 0,45 * * * *", nil, nil},
+		{"0 10-40/10 * * * *", nil, nil},
+		{"0 0 */5 * * *", nil, []Warning{
+			{CodeUnevenStep, SeverityWarning, Hour, "step 5 doesn't divide the 24 hours, 20 is followed by 0 after 4 instead of 5"},
+		}},
+		{"0 0 0 1 */5 *", nil, []Warning{
+			{CodeUnevenStep, SeverityWarning, Month, "step 5 doesn't divide the 12 months, NOV is followed by JAN after 2 instead of 5"},
+		}},
+		{"0 0 0 * * */2", nil, []Warning{
+			{CodeUnevenStep, SeverityWarning, DOW, "step 2 doesn't divide the 7 days of week, SAT is followed by SUN after 1 instead of 2"},
+		}},
+		{"0 0 0 31 * *", nil, []Warning{
+			{CodeMissingDay, SeverityWarning, DOM, "day 31 is skipped in FEB,APR,JUN,SEP,NOV"},
+		}},
+		{"0 0 0 1,15,30 1-6 *", nil, []Warning{
+			{CodeMissingDay, SeverityWarning, DOM, "day 30 is skipped in FEB"},
+		}},
+		{"0 0 0 29 * *", nil, []Warning{
+			{CodeMissingDay, SeverityInfo, DOM, "day 29 is skipped in FEB except in leap years"},
+		}},
+		{"0 0 0 31 JAN,MAR *", nil, nil},
+		{"0 0 0 L * *", nil, nil},
+		{"0 75 * * * *", nil, []Warning{
+			{CodeParseError, SeverityError, 0, `failed to parse minutes: value out of range "75" at offset 2`},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			actual := Lint(tt.expression, tt.opts...)
+			if len(actual) != len(tt.expected) {
+				t.Fatalf("Expected: %v Actual: %v", tt.expected, actual)
+			}
+			for i := range actual {
+				if actual[i] != tt.expected[i] {
+					t.Errorf("Expected: %v Actual: %v", tt.expected[i], actual[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWarningString(t *testing.T) {
+	warnings := Lint("0 */7 * * * *")
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning Actual: %v", warnings)
+	}
+	expected := "warning: minutes: step 7 doesn't divide the 60 minutes, 56 is followed by 0 after 4 instead of 7"
+	if actual := warnings[0].String(); actual != expected {
+		t.Errorf("Expected: %q Actual: %q", expected, actual)
+	}
+}
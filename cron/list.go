@@ -0,0 +1,38 @@
+package cron
+
+import "sort"
+
+// isListField reports whether the field keeps its values in CronField.list, years and milliseconds don't fit into
+// CronField.bits.
+func (cr *CronField) isListField() bool {
+	return cr.fieldType == Year || cr.fieldType == Millisecond
+}
+
+// addToList records the value in the sorted list.
+func (cr *CronField) addToList(value int) {
+	i := sort.SearchInts(cr.list, value)
+	if i < len(cr.list) && cr.list[i] == value {
+		return
+	}
+	cr.list = append(cr.list, 0)
+	copy(cr.list[i+1:], cr.list[i:])
+	cr.list[i] = value
+}
+
+// nextInList returns the first listed value at or after the given one, -1 if there is none.
+func (cr *CronField) nextInList(value int) int {
+	i := sort.SearchInts(cr.list, value)
+	if i == len(cr.list) {
+		return -1
+	}
+	return cr.list[i]
+}
+
+// prevInList returns the last listed value at or before the given one, -1 if there is none.
+func (cr *CronField) prevInList(value int) int {
+	i := sort.SearchInts(cr.list, value+1)
+	if i == 0 {
+		return -1
+	}
+	return cr.list[i-1]
+}
@@ -0,0 +1,136 @@
+package cron
+
+import "time"
+
+// nextInLocation searches matching wall clock times and maps them to instants in the location of the expression,
+// applying the gap and overlap policies around daylight saving time transitions.
+func (cr *CronExpression) nextInLocation(from time.Time) time.Time {
+	location := cr.location
+	if location == nil {
+		location = from.Location()
+	}
+	from = from.In(location)
+	if precision := cr.precision(); !from.Truncate(precision).Equal(from) {
+		// fire times are whole seconds, or milliseconds
+		from = from.Truncate(precision).Add(precision)
+	}
+	wall := wallClock(from)
+	if start, _ := from.ZoneBounds(); start.Equal(from) {
+		// wall clock times skipped by a gap are mapped to the transition instant itself
+		if before, after := offsets(from); after > before {
+			wall = wall.Add(-time.Duration(after-before) * time.Second)
+		}
+	}
+	if _, end := from.ZoneBounds(); cr.overlapPolicy == OverlapRunTwice && !end.IsZero() {
+		// the second occurrence of an overlap may be after from even though its wall clock time is before
+		if before, after := offsets(end); after < before && end.Sub(from) <= time.Duration(before-after)*time.Second {
+			// but the first occurrences up to the transition come before it
+			if first := cr.nextWall(wall); !first.IsZero() && from.Add(first.Sub(wall)).Before(end) {
+				return from.Add(first.Sub(wall))
+			}
+			wall = wallClock(end)
+		}
+	}
+
+	const maxIterations = 366
+	for i := 0; i < maxIterations; i++ {
+		wall = cr.nextWall(wall)
+		if wall.IsZero() {
+			return wall
+		}
+		for _, candidate := range cr.resolve(wall, location) {
+			if !candidate.Before(from) {
+				return candidate
+			}
+		}
+		wall = wall.Add(cr.precision())
+	}
+	return time.Time{}
+}
+
+// prevInLocation is the reverse of nextInLocation.
+func (cr *CronExpression) prevInLocation(from time.Time) time.Time {
+	location := cr.location
+	if location == nil {
+		location = from.Location()
+	}
+	from = from.In(location)
+	wall := wallClock(from)
+	if start, _ := from.ZoneBounds(); !start.IsZero() {
+		// the first occurrence of an overlap may be before from even though its wall clock time is after
+		if before, after := offsets(start); after < before && from.Sub(start) < time.Duration(before-after)*time.Second {
+			// but the second occurrences since the transition come after it
+			if last := cr.prevWall(wall); cr.overlapPolicy == OverlapRunTwice && !last.IsZero() && !from.Add(last.Sub(wall)).Before(start) {
+				return from.Add(last.Sub(wall))
+			}
+			wall = wallClock(start.Add(-time.Nanosecond))
+		}
+	}
+
+	const maxIterations = 366
+	for i := 0; i < maxIterations; i++ {
+		wall = cr.prevWall(wall)
+		if wall.IsZero() {
+			return wall
+		}
+		candidates := cr.resolve(wall, location)
+		for j := len(candidates) - 1; j >= 0; j-- {
+			if !candidates[j].After(from) {
+				return candidates[j]
+			}
+		}
+		wall = wall.Add(-cr.precision())
+	}
+	return time.Time{}
+}
+
+// resolve maps a wall clock time to the instants it stands for in the location.
+func (cr *CronExpression) resolve(wall time.Time, location *time.Location) []time.Time {
+	t := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), location)
+	if local := wallClock(t); !local.Equal(wall) {
+		if cr.gapPolicy == GapSkip {
+			return nil
+		}
+		start, end := t.ZoneBounds()
+		if local.After(wall) {
+			return []time.Time{start}
+		}
+		return []time.Time{end}
+	}
+
+	start, end := t.ZoneBounds()
+	if !start.IsZero() {
+		if before, after := offsets(start); after < before {
+			if first := t.Add(-time.Duration(before-after) * time.Second); first.Before(start) {
+				return cr.overlap(first, t)
+			}
+		}
+	}
+	if !end.IsZero() {
+		if before, after := offsets(end); after < before {
+			if second := t.Add(time.Duration(before-after) * time.Second); !second.Before(end) {
+				return cr.overlap(t, second)
+			}
+		}
+	}
+	return []time.Time{t}
+}
+
+func (cr *CronExpression) overlap(first, second time.Time) []time.Time {
+	if cr.overlapPolicy == OverlapRunTwice {
+		return []time.Time{first, second}
+	}
+	return []time.Time{first}
+}
+
+// offsets returns the zone offsets in seconds right before and at the transition.
+func offsets(transition time.Time) (before, after int) {
+	_, before = transition.Add(-time.Second).Zone()
+	_, after = transition.Zone()
+	return before, after
+}
+
+// wallClock returns the wall clock time of t as UTC, so field arithmetic isn't affected by zone transitions.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
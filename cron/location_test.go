@@ -0,0 +1,92 @@
+package cron
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestNextInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	tests := []struct {
+		now        string
+		expression string
+		options    []Option
+		expected   string // UTC
+	}{
+		// Location from prefix or option
+		{"2023-06-01 12:00", "CRON_TZ=America/New_York 0 0 9 * * *", nil, "2023-06-01 13:00"},
+		{"2023-06-01 12:00", "TZ=America/New_York 0 0 9 * * *", nil, "2023-06-01 13:00"},
+		{"2023-06-01 12:00", "0 0 9 * * *", []Option{WithLocation(newYork)}, "2023-06-01 13:00"},
+		{"2023-06-01 12:00", "CRON_TZ=UTC 0 0 9 * * *", []Option{WithLocation(newYork)}, "2023-06-02 09:00"},
+		{"2023-01-01 12:00", "CRON_TZ=Europe/Berlin @daily", nil, "2023-01-01 23:00"},
+
+		// Gap, Berlin moves from 02:00 CET to 03:00 CEST on 2023-03-26
+		{"2023-03-25 02:00", "CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-03-26 01:00"},
+		{"2023-03-26 01:00", "CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-03-26 01:00"},
+		{"2023-03-26 01:00:01", "CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-03-27 00:30"},
+		{"2023-03-26 00:30", "CRON_TZ=Europe/Berlin 0 */15 2 * * *", nil, "2023-03-26 01:00"},
+		{"2023-03-26 01:00:01", "CRON_TZ=Europe/Berlin 0 */15 2 * * *", nil, "2023-03-27 00:00"},
+		{"2023-03-25 02:00", "CRON_TZ=Europe/Berlin 0 30 2 * * *", []Option{WithGapPolicy(GapSkip)}, "2023-03-27 00:30"},
+		{"2023-03-26 00:59", "CRON_TZ=Europe/Berlin 0 0 * * * *", nil, "2023-03-26 01:00"},
+		{"2023-03-26 01:00:01", "CRON_TZ=Europe/Berlin 0 0 * * * *", nil, "2023-03-26 02:00"},
+
+		// Overlap, Berlin moves from 03:00 CEST back to 02:00 CET on 2023-10-29
+		{"2023-10-28 23:00", "CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-10-29 00:30"},
+		{"2023-10-29 00:30:01", "CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-10-30 01:30"},
+		{"2023-10-29 00:30:01", "CRON_TZ=Europe/Berlin 0 30 2 * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}, "2023-10-29 01:30"},
+		{"2023-10-29 00:40", "CRON_TZ=Europe/Berlin 0 30 2 * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}, "2023-10-29 01:30"},
+		{"2023-10-29 01:30:01", "CRON_TZ=Europe/Berlin 0 30 2 * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}, "2023-10-30 01:30"},
+		{"2023-10-29 00:00:01", "CRON_TZ=Europe/Berlin 0 0 * * * *", nil, "2023-10-29 02:00"},
+		{"2023-10-29 00:00:01", "CRON_TZ=Europe/Berlin 0 0 * * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}, "2023-10-29 01:00"},
+		{"2023-10-29 00:00:01", "CRON_TZ=Europe/Berlin 0 */20 * * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}, "2023-10-29 00:20"},
+		{"2023-10-29 00:40:01", "CRON_TZ=Europe/Berlin 0 */20 * * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}, "2023-10-29 01:00"},
+
+		// New York moves from 02:00 EST to 03:00 EDT on 2023-03-12 and back on 2023-11-05
+		{"2023-03-11 12:00", "0 30 2 * * *", []Option{WithLocation(newYork)}, "2023-03-12 07:00"},
+		{"2023-11-05 05:00", "0 30 1 * * *", []Option{WithLocation(newYork)}, "2023-11-05 05:30"},
+		{"2023-11-05 05:30:01", "0 30 1 * * *", []Option{WithLocation(newYork)}, "2023-11-06 06:30"},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression, tt.options...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			actual := cr.Next(parseTime(tt.now))
+			expected := parseTime(tt.expected)
+			if !actual.Equal(expected) {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+
+	t.Run("result location", func(t *testing.T) {
+		cr, err := Parse("0 0 9 * * *", WithLocation(berlin))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if actual := cr.Next(parseTime("2023-06-01 12:00")); actual.Location() != berlin {
+			t.Errorf("Expected location %v Actual: %v", berlin, actual.Location())
+		}
+	})
+}
+
+func TestParseLocationErrors(t *testing.T) {
+	for _, expression := range []string{"CRON_TZ=Mars/Olympus 0 0 0 * * *", "CRON_TZ=UTC", "TZ=Europe/Berlin * *"} {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Parse(expression); err == nil {
+				t.Errorf("Expected error for %q", expression)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package cron
+
+import "fmt"
+
+// MarshalText implements encoding.TextMarshaler, it returns the canonical form of the expression, see String.
+// Options the text can't carry, like WithJitter or WithCalendar, make it fail rather than change the schedule.
+// It has a value receiver so CronExpression fields are marshalled by value too.
+func (cr CronExpression) MarshalText() ([]byte, error) {
+	if option := cr.textless(); option != "" {
+		return nil, fmt.Errorf("failed to marshal %q: %s can't be represented as text", cr.String(), option)
+	}
+	return []byte(cr.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, it parses the text with Parse and returns its error, if any.
+// Empty text, which MarshalText returns for the zero value, unmarshals to the zero value, so optional schedules round
+// trip.
+func (cr *CronExpression) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*cr = CronExpression{}
+		return nil
+	}
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*cr = *parsed
+	return nil
+}
+
+// textless returns the first option of the expression Parse can't read back from its text, if any.
+func (cr *CronExpression) textless() string {
+	switch {
+	case cr.gapPolicy != GapRunAtTransition:
+		return "the gap policy"
+	case cr.overlapPolicy != OverlapRunOnce:
+		return "the overlap policy"
+	case cr.dayPolicy != DayAnd:
+		return "the day policy"
+	case cr.milliseconds:
+		// without WithMilliseconds the leading field is read as seconds
+		return "the millisecond field"
+	case cr.jitter > 0:
+		return "the jitter"
+	case cr.calendar != nil:
+		return "the calendar"
+	}
+	return ""
+}
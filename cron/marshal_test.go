@@ -0,0 +1,158 @@
+package cron
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+type config struct {
+	Schedule CronExpression  `json:"schedule"`
+	Optional *CronExpression `json:"optional,omitempty"`
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	var c config
+	if err := json.Unmarshal([]byte(`{"schedule": "0 0/15 * * * *", "optional": "@daily"}`), &c); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if actual, expected := c.Schedule.Next(parseTime("2012-07-09 14:46")), parseTime("2012-07-09 15:00"); actual != expected {
+		t.Errorf("Expected: %v Actual: %v", expected, actual)
+	}
+	if actual, expected := c.Optional.Next(parseTime("2012-07-09 14:46")), parseTime("2012-07-10 00:00"); actual != expected {
+		t.Errorf("Expected: %v Actual: %v", expected, actual)
+	}
+}
+
+func TestUnmarshalJSONError(t *testing.T) {
+	var c config
+	err := json.Unmarshal([]byte(`{"schedule": "0 75 * * * *"}`), &c)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected ParseError, actual: %v", err)
+	}
+	if parseErr.Field != Minute || parseErr.Reason != ReasonOutOfRange {
+		t.Errorf("Unexpected error: %#v", parseErr)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	schedule, err := Parse("CRON_TZ=Europe/Berlin 0 30 9 * * MON-FRI")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	tests := []struct {
+		value    interface{}
+		expected string
+	}{
+		{config{Schedule: *schedule}, `{"schedule":"CRON_TZ=Europe/Berlin 0 30 9 * * MON-FRI"}`},
+		{&config{Schedule: *schedule, Optional: schedule}, `{"schedule":"CRON_TZ=Europe/Berlin 0 30 9 * * MON-FRI","optional":"CRON_TZ=Europe/Berlin 0 30 9 * * MON-FRI"}`},
+		{config{}, `{"schedule":""}`},
+		{CronExpression{fields: schedule.fields}, `"0 30 9 * * MON-FRI"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			actual, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if string(actual) != tt.expected {
+				t.Errorf("Expected: %s Actual: %s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMarshalTextRoundTrip(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	for _, expression := range []string{"0 0 9 * * *", "@daily", "@every 90m", "H H * * *"} {
+		t.Run(expression, func(t *testing.T) {
+			cr, err := Parse(expression, WithLocation(berlin), WithHashSeed("job"))
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			text, err := cr.MarshalText()
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			var decoded CronExpression
+			if err := decoded.UnmarshalText(text); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !decoded.Equal(cr) {
+				t.Errorf("Expected: %s Actual: %s", cr, &decoded)
+			}
+		})
+	}
+}
+
+func TestMarshalTextError(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		opts       []Option
+	}{
+		{"gap policy", "0 0 9 * * *", []Option{WithGapPolicy(GapSkip)}},
+		{"overlap policy", "0 0 9 * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}},
+		{"day policy", "0 0 0 1 * MON", []Option{WithDayPolicy(DayVixie)}},
+		{"milliseconds", "250 * * * * * *", []Option{WithMilliseconds()}},
+		{"jitter", "0 0 9 * * *", []Option{WithJitter(time.Minute)}},
+		{"calendar", "0 0 9 * * *", []Option{WithCalendar(new(Calendar))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr, err := Parse(tt.expression, tt.opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if _, err := json.Marshal(config{Schedule: *cr}); err == nil {
+				t.Errorf("Expected error for %s", tt.name)
+			}
+		})
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	var cr CronExpression
+	now := parseTime("2012-07-09 14:45")
+	if actual := cr.String(); actual != "" {
+		t.Errorf("Expected: %q Actual: %q", "", actual)
+	}
+	if actual := cr.Describe(); actual != "" {
+		t.Errorf("Expected: %q Actual: %q", "", actual)
+	}
+	if actual := cr.Next(now); !actual.IsZero() {
+		t.Errorf("Expected: %v Actual: %v", time.Time{}, actual)
+	}
+	if actual := cr.Prev(now); !actual.IsZero() {
+		t.Errorf("Expected: %v Actual: %v", time.Time{}, actual)
+	}
+	if cr.Matches(now) {
+		t.Errorf("Expected the zero value to match nothing")
+	}
+	if actual := cr.Stats(now, now.Add(time.Hour)); actual != (Stats{}) {
+		t.Errorf("Expected: %+v Actual: %+v", Stats{}, actual)
+	}
+	data, err := json.Marshal(struct{ S CronExpression }{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if expected := `{"S":""}`; string(data) != expected {
+		t.Errorf("Expected: %s Actual: %s", expected, data)
+	}
+	parsed, err := Parse("0 0 * * * *")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	roundTrip := struct{ S CronExpression }{*parsed}
+	if err := json.Unmarshal(data, &roundTrip); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !roundTrip.S.isZero() {
+		t.Errorf("Expected the zero value Actual: %q", roundTrip.S.String())
+	}
+}
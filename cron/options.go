@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"math/rand"
+	"time"
+)
+
+// GapPolicy defines what happens to occurrences falling into a daylight saving time gap,
+// i.e. wall clock times skipped when the clocks move forward.
+type GapPolicy int
+
+const (
+	// GapRunAtTransition fires once at the transition instant for all occurrences inside the gap.
+	GapRunAtTransition GapPolicy = iota
+	// GapSkip drops occurrences inside the gap.
+	GapSkip
+)
+
+// OverlapPolicy defines what happens to occurrences falling into a daylight saving time overlap,
+// i.e. wall clock times repeated when the clocks move backward.
+type OverlapPolicy int
+
+const (
+	// OverlapRunOnce fires only at the first of the two instants with the same wall clock time.
+	OverlapRunOnce OverlapPolicy = iota
+	// OverlapRunTwice fires at both instants with the same wall clock time.
+	OverlapRunTwice
+)
+
+// DayPolicy defines how the day of month and day of week fields are combined.
+type DayPolicy int
+
+const (
+	// DayAnd fires on days matching both fields.
+	DayAnd DayPolicy = iota
+	// DayVixie fires on days matching either field when both are restricted, i.e. neither is * or ?, like Vixie cron.
+	DayVixie
+	// DayQuartz fires on days matching both fields like DayAnd, but Parse rejects expressions restricting both,
+	// one of them must be * or ? like in Quartz.
+	DayQuartz
+)
+
+// Option configures Parse.
+type Option func(*options)
+
+type options struct {
+	location      *time.Location
+	gapPolicy     GapPolicy
+	overlapPolicy OverlapPolicy
+	dayPolicy     DayPolicy
+	milliseconds  bool
+	hashSeed      string
+	source        rand.Source
+	jitter        time.Duration
+	random        *lockedRand
+	calendar      *Calendar
+}
+
+// WithLocation evaluates the expression in the given location, a CRON_TZ= prefix of the expression takes precedence.
+func WithLocation(location *time.Location) Option {
+	return func(o *options) {
+		o.location = location
+	}
+}
+
+// WithGapPolicy sets the daylight saving time gap policy, GapRunAtTransition by default.
+func WithGapPolicy(policy GapPolicy) Option {
+	return func(o *options) {
+		o.gapPolicy = policy
+	}
+}
+
+// WithOverlapPolicy sets the daylight saving time overlap policy, OverlapRunOnce by default.
+func WithOverlapPolicy(policy OverlapPolicy) Option {
+	return func(o *options) {
+		o.overlapPolicy = policy
+	}
+}
+
+// WithDayPolicy sets how the day of month and day of week fields are combined, DayAnd by default.
+func WithDayPolicy(policy DayPolicy) Option {
+	return func(o *options) {
+		o.dayPolicy = policy
+	}
+}
+
+// WithMilliseconds expects a leading millisecond field (0-999) before the seconds, e.g. "250,750 * * * * *" fires at
+// 250ms and 750ms past every second. The seconds field is required then, the five-field form is not accepted.
+func WithMilliseconds() Option {
+	return func(o *options) {
+		o.milliseconds = true
+	}
+}
+
+// WithHashSeed sets the seed H tokens are resolved from, e.g. a job ID, so the same job always fires at the same
+// spread-out time while different jobs are spread over the field range.
+func WithHashSeed(seed string) Option {
+	return func(o *options) {
+		o.hashSeed = seed
+	}
+}
+
+// WithRandSource sets the source R tokens and jitter are picked from, a time seeded source is used by default.
+func WithRandSource(source rand.Source) Option {
+	return func(o *options) {
+		o.source = source
+	}
+}
+
+// WithJitter delays every time returned by Next by a random duration in [0, max).
+func WithJitter(max time.Duration) Option {
+	return func(o *options) {
+		o.jitter = max
+	}
+}
+
+// WithCalendar skips the instants excluded by the calendar.
+func WithCalendar(calendar *Calendar) Option {
+	return func(o *options) {
+		o.calendar = calendar
+	}
+}
@@ -0,0 +1,90 @@
+package cron
+
+import "time"
+
+// Overlaps reports whether a and b fire at the same instant at or after start and before end.
+// @every expressions are anchored to the time Next is called with, so they never collide.
+func Overlaps(a, b *CronExpression, start, end time.Time) bool {
+	t := collisions(a, b).Next(start)
+	return !t.IsZero() && t.Before(end)
+}
+
+// CollisionTimes returns the instants at or after start and before end at which both a and b fire.
+func CollisionTimes(a, b *CronExpression, start, end time.Time) []time.Time {
+	s := collisions(a, b)
+	var times []time.Time
+	for t := s.Next(start); !t.IsZero() && t.Before(end); t = s.Next(t.Add(time.Nanosecond)) {
+		times = append(times, t)
+	}
+	return times
+}
+
+// collisions returns a schedule firing when both a and b fire. If possible the fields are intersected bitwise, so
+// only the common fire times are visited, otherwise the schedules are leapfrogged, see Intersect.
+func collisions(a, b *CronExpression) Schedule {
+	if a.interval > 0 || b.interval > 0 {
+		// the empty union never fires
+		return union{}
+	}
+	if s, ok := intersect(a, b); ok {
+		return s
+	}
+	return Intersect(a, b)
+}
+
+// intersect returns an expression whose fields are the intersection of the fields of a and b. ok is false when
+// the fields can't be intersected bitwise: with day rules, DayVixie, calendars, jitter or different locations.
+func intersect(a, b *CronExpression) (s Schedule, ok bool) {
+	if a.calendar != nil || b.calendar != nil || a.jitter > 0 || b.jitter > 0 || a.eitherDay() || b.eitherDay() ||
+		a.gapPolicy != b.gapPolicy || a.overlapPolicy != b.overlapPolicy || !sameLocation(a.location, b.location) {
+		return nil, false
+	}
+	cr := &CronExpression{options: options{location: a.location, gapPolicy: a.gapPolicy, overlapPolicy: a.overlapPolicy}}
+	for _, fieldType := range []CronFieldType{Year, DOW, Month, DOM, Hour, Minute, Second, Millisecond} {
+		fa, fb := a.field(fieldType), b.field(fieldType)
+		if fieldType == Millisecond && fa == nil && fb == nil {
+			continue
+		}
+		if fieldType == Millisecond {
+			// expressions without milliseconds fire at millisecond 0
+			fa, fb = orZeroMillis(fa), orZeroMillis(fb)
+		}
+		switch {
+		case fa == nil && fb == nil:
+			continue
+		case fa == nil:
+			fa = fb
+		case fb == nil:
+			fb = fa
+		}
+		if len(fa.rules) > 0 || len(fb.rules) > 0 {
+			return nil, false
+		}
+		field := CronField{fieldType: fieldType, fieldRange: fa.fieldRange, bits: fa.bits & fb.bits}
+		for _, value := range fa.list {
+			if fb.nextInList(value) == value {
+				field.list = append(field.list, value)
+			}
+		}
+		if field.bits == 0 && len(field.list) == 0 {
+			// they never fire in the same second, minute, ...
+			return union{}, true
+		}
+		cr.fields = append(cr.fields, field)
+	}
+	return cr, true
+}
+
+func orZeroMillis(field *CronField) *CronField {
+	if field != nil {
+		return field
+	}
+	return &CronField{fieldType: Millisecond, fieldRange: fieldRange[Millisecond], list: []int{0}}
+}
+
+func sameLocation(a, b *time.Location) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
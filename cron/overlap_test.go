@@ -0,0 +1,45 @@
+package cron
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestCollisionTimes(t *testing.T) {
+	tests := []struct {
+		a, b       string
+		start, end string
+		expected   []string
+	}{
+		{"0 */15 * * * *", "0 */20 * * * *", "2012-07-09 14:00", "2012-07-09 16:00", []string{"2012-07-09 14:00", "2012-07-09 15:00"}},
+		{"0 0 9 * * MON", "0 0 9 13 * *", "2012-07-09 14:00", "2013-07-09 14:00", []string{"2012-08-13 09:00", "2013-05-13 09:00"}},
+		{"0 * * * * *", "30 * * * * *", "2012-07-09 14:00", "2013-07-09 14:00", nil},
+		{"0 0 12 L * *", "0 0 12 * * FRI", "2012-07-09 14:00", "2012-12-31 14:00", []string{"2012-08-31 12:00", "2012-11-30 12:00"}},
+		{"0 0 12 1 * *", "0 0 12 * * MON 2013", "2012-07-09 14:00", "2014-01-01 00:00", []string{"2013-04-01 12:00", "2013-07-01 12:00"}},
+		{"CRON_TZ=Europe/Berlin 0 0 9 * * *", "0 0 7 * * *", "2012-07-09 00:00", "2012-07-11 00:00", []string{"2012-07-09 07:00", "2012-07-10 07:00"}},
+		{"CRON_TZ=Europe/Berlin 0 0 9 1 * *", "CRON_TZ=Europe/Berlin 0 0 9 * 1 *", "2012-07-09 00:00", "2014-07-11 00:00", []string{"2013-01-01 08:00", "2014-01-01 08:00"}},
+		{"@every 1h", "0 0 * * * *", "2012-07-09 14:00", "2012-07-10 14:00", nil},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			a, err := Parse(tt.a)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			b, err := Parse(tt.b)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			start, end := parseTime(tt.start), parseTime(tt.end)
+			var actual []time.Time
+			for _, collision := range CollisionTimes(a, b, start, end) {
+				actual = append(actual, collision.UTC())
+			}
+			assertTimes(t, tt.expected, actual)
+			if expected, actual := len(tt.expected) > 0, Overlaps(a, b, start, end); actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package cron
+
+import (
+	mathbits "math/bits"
+	"time"
+)
+
+// Prev returns the last time at or before from matching the expression. For @every expressions it returns from shifted back by the interval.
+// The result is in the location of the expression, or in the location of from if the expression has none.
+// The zero time is returned when there is no such time, or for the zero CronExpression.
+func (cr *CronExpression) Prev(from time.Time) time.Time {
+	if cr.isZero() {
+		return time.Time{}
+	}
+	if cr.interval > 0 {
+		return cr.skipExcludedBackward(from.Add(-cr.interval))
+	}
+	return cr.skipExcludedBackward(cr.prevInLocation(from))
+}
+
+// prevWall finds the previous matching wall clock time, from must be in UTC.
+// Unlike stepping through every unit it jumps to the previous set bit of each field, carrying into the higher fields.
+func (cr *CronExpression) prevWall(from time.Time) time.Time {
+	precision := cr.precision()
+	// nanoseconds of the last fire time within a second
+	last := int(time.Second - precision)
+	t := from.Truncate(precision)
+	months := cr.field(Month)
+	hours, minutes, seconds, millis := cr.field(Hour), cr.field(Minute), cr.field(Second), cr.field(Millisecond)
+	const maxIterations = 10000
+	for i := 0; i < maxIterations; i++ {
+		y, m, d := t.Date()
+		if years := cr.field(Year); years != nil {
+			year := years.prevInList(y)
+			if year == -1 {
+				return time.Time{}
+			}
+			if year != y {
+				t = time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-precision)
+				continue
+			}
+		}
+		if month := months.Prev(int(m)); month != int(m) {
+			if month == -1 {
+				t = time.Date(y, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-precision)
+			} else {
+				t = time.Date(y, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC).Add(-precision)
+			}
+			continue
+		}
+		if !cr.matchesDays(t) {
+			t = time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Add(-precision)
+			continue
+		}
+		if hour := hours.Prev(t.Hour()); hour != t.Hour() {
+			if hour == -1 {
+				t = time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Add(-precision)
+			} else {
+				t = time.Date(y, m, d, hour, 59, 59, last, time.UTC)
+			}
+			continue
+		}
+		if minute := minutes.Prev(t.Minute()); minute != t.Minute() {
+			if minute == -1 {
+				t = time.Date(y, m, d, t.Hour(), 0, 0, 0, time.UTC).Add(-precision)
+			} else {
+				t = time.Date(y, m, d, t.Hour(), minute, 59, last, time.UTC)
+			}
+			continue
+		}
+		if second := seconds.Prev(t.Second()); second != t.Second() {
+			if second == -1 {
+				t = time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, time.UTC).Add(-precision)
+			} else {
+				t = time.Date(y, m, d, t.Hour(), t.Minute(), second, last, time.UTC)
+			}
+			continue
+		}
+		if millis != nil {
+			current := millis.getPartOfTime(t)
+			if ms := millis.prevInList(current); ms != current {
+				if ms == -1 {
+					t = time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, time.UTC).Add(-precision)
+				} else {
+					t = time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), ms*int(time.Millisecond), time.UTC)
+				}
+				continue
+			}
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (cr *CronExpression) field(fieldType CronFieldType) *CronField {
+	for i := range cr.fields {
+		if cr.fields[i].fieldType == fieldType {
+			return &cr.fields[i]
+		}
+	}
+	return nil
+}
+
+// Prev returns the highest set bit at or below idx, -1 if there is none.
+func (cr *CronField) Prev(idx int) int {
+	result := uint64(cr.bits) & (1<<(idx+1) - 1)
+	if result == 0 {
+		return -1
+	}
+	return mathbits.Len64(result) - 1
+}
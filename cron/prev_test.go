@@ -0,0 +1,127 @@
+package cron
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestPrev(t *testing.T) {
+	tests := []struct {
+		now        string
+		expression string
+		expected   string
+	}{
+		{"2012-07-09 14:46", "0 0/15 * * * *", "2012-07-09 14:45"},
+		{"2012-07-09 14:45", "0 0/15 * * * *", "2012-07-09 14:45"},
+		{"2012-07-09 14:44:59", "0 0/15 * * * *", "2012-07-09 14:30"},
+
+		// shift hours and days
+		{"2012-07-09 16:10", "0 20-35/15 * * * *", "2012-07-09 15:35"},
+		{"2012-07-10 00:10", "0 20-35/15 * * * *", "2012-07-09 23:35"},
+		{"2012-07-10 00:20:14", "15/35 20-35/15 * * * *", "2012-07-09 23:35:50"},
+		{"2012-07-10 09:00", "15/35 20-35/15 10-12 * * *", "2012-07-09 12:35:50"},
+
+		// Wrap around months and years
+		{"2012-07-09 23:35", "0 0 0 9 Apr-Oct ?", "2012-07-09 00:00"},
+		{"2012-07-08 23:35", "0 0 0 9 Apr-Oct ?", "2012-06-09 00:00"},
+		{"2012-07-09 23:35", "0 0 0 * Feb Mon", "2012-02-27 00:00"},
+		{"2013-01-01 00:00", "0 * * * * *", "2013-01-01 00:00"},
+		{"2012-12-31 23:59:59", "0 * * * * *", "2012-12-31 23:59"},
+
+		// Leap year
+		{"2015-07-09 23:35", "0 0 0 29 Feb ?", "2012-02-29 00:00"},
+
+		// Day rules
+		{"2012-07-09 23:35", "0 0 12 L * *", "2012-06-30 12:00"},
+		{"2012-03-01 00:00", "0 0 12 L * *", "2012-02-29 12:00"},
+		{"2012-10-01 00:00", "0 0 0 LW * *", "2012-09-28 00:00"},
+		{"2012-07-09 23:35", "0 0 9 * * MON#2", "2012-07-09 09:00"},
+		{"2012-07-09 08:00", "0 0 9 * * MON#2", "2012-06-11 09:00"},
+
+		// Years
+		{"2012-07-09 23:35", "0 0 0 1 1 * 2010", "2010-01-01 00:00"},
+		{"2009-07-09 23:35", "0 0 0 1 1 * 2010", ""},
+
+		// Intervals
+		{"2012-07-09 23:35", "@every 1h", "2012-07-09 22:35"},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			actual := cr.Prev(parseTime(tt.now))
+			expected := parseTime(tt.expected)
+			if actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestPrevInLocation(t *testing.T) {
+	tests := []struct {
+		now        string
+		expression string
+		options    []Option
+		expected   string // UTC
+	}{
+		// Gap, Berlin moves from 02:00 CET to 03:00 CEST on 2023-03-26
+		{"2023-03-26 02:00", "CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-03-26 01:00"},
+		{"2023-03-26 00:59", "CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-03-25 01:30"},
+		{"2023-03-26 02:00", "CRON_TZ=Europe/Berlin 0 30 2 * * *", []Option{WithGapPolicy(GapSkip)}, "2023-03-25 01:30"},
+
+		// Overlap, Berlin moves from 03:00 CEST back to 02:00 CET on 2023-10-29
+		{"2023-10-29 01:10", "CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-10-29 00:30"},
+		{"2023-10-29 01:40", "CRON_TZ=Europe/Berlin 0 30 2 * * *", nil, "2023-10-29 00:30"},
+		{"2023-10-29 01:40", "CRON_TZ=Europe/Berlin 0 30 2 * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}, "2023-10-29 01:30"},
+		{"2023-10-29 01:29", "CRON_TZ=Europe/Berlin 0 30 2 * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}, "2023-10-29 00:30"},
+		{"2023-10-29 01:19", "CRON_TZ=Europe/Berlin 0 */20 * * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}, "2023-10-29 01:00"},
+		{"2023-10-29 01:19", "CRON_TZ=Europe/Berlin 0 */20 * * * *", nil, "2023-10-29 00:40"},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression, tt.options...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			actual := cr.Prev(parseTime(tt.now))
+			expected := parseTime(tt.expected)
+			if !actual.Equal(expected) {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		start      string
+		end        string
+		expression string
+		expected   []string
+	}{
+		{"2012-07-09 10:00", "2012-07-09 13:30", "0 0 * * * *", []string{"2012-07-09 13:00", "2012-07-09 12:00", "2012-07-09 11:00", "2012-07-09 10:00"}},
+		{"2012-07-09 10:00:01", "2012-07-09 13:00", "0 0 * * * *", []string{"2012-07-09 13:00", "2012-07-09 12:00", "2012-07-09 11:00"}},
+		{"2012-01-01 00:00", "2012-12-31 00:00", "0 0 0 L 2,4 *", []string{"2012-04-30 00:00", "2012-02-29 00:00"}},
+		{"2012-07-09 10:00", "2012-07-09 10:30", "0 0 * * * *", []string{"2012-07-09 10:00"}},
+		{"2012-07-09 10:01", "2012-07-09 10:30", "0 0 * * * *", nil},
+		{"2012-07-09 10:00", "2012-07-09 12:00", "@every 45m", []string{"2012-07-09 11:15", "2012-07-09 10:30"}},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			var actual []time.Time
+			it := cr.Between(parseTime(tt.start), parseTime(tt.end))
+			for next, ok := it.Next(); ok; next, ok = it.Next() {
+				actual = append(actual, next)
+			}
+			assertTimes(t, tt.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// lockedRand guards a rand.Rand, an expression may be used by several goroutines.
+type lockedRand struct {
+	sync.Mutex
+	rand *rand.Rand
+}
+
+func (r *lockedRand) Intn(n int) int {
+	r.Lock()
+	defer r.Unlock()
+	return r.rand.Intn(n)
+}
+
+func (r *lockedRand) Int63n(n int64) int64 {
+	r.Lock()
+	defer r.Unlock()
+	return r.rand.Int63n(n)
+}
+
+// rand returns the random source of the options, creating it on first use.
+func (o *options) rand() *lockedRand {
+	if o.random == nil {
+		source := o.source
+		if source == nil {
+			source = rand.NewSource(time.Now().UnixNano())
+		}
+		o.random = &lockedRand{rand: rand.New(source)}
+	}
+	return o.random
+}
+
+// parseRandom resolves R and R(a-b) tokens into a random value, ok is false when the token is not a random token.
+func parseRandom(part token, fieldType CronFieldType, o *options) (values []int, ok bool, err error) {
+	if !strings.HasPrefix(part.value, "R") {
+		return nil, false, nil
+	}
+	r, rest, err := parseSpreadRange(part, fieldType)
+	if err != nil {
+		return nil, true, err
+	}
+	if rest.value != "" {
+		return nil, true, newParseError(fieldType, part, ReasonInvalidValue)
+	}
+	return []int{r.min + o.rand().Intn(r.max-r.min+1)}, true, nil
+}
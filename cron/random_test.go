@@ -0,0 +1,122 @@
+package cron
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRandom(t *testing.T) {
+	tests := []struct {
+		expression string
+		fieldType  CronFieldType
+		min        int
+		max        int
+	}{
+		{"0 R * * * *", Minute, 0, 59},
+		{"R * * * * *", Second, 0, 59},
+		{"0 R(10-20) * * * *", Minute, 10, 20},
+		{"0 0 R(9-17) * * *", Hour, 9, 17},
+		{"0 0 0 R * *", DOM, 1, 28},
+		{"0 0 0 * * R", DOW, 0, 6},
+		{"0 0 0 * R(jan-mar) *", Month, 1, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			seen := map[int]bool{}
+			for seed := int64(0); seed < 50; seed++ {
+				cr, err := Parse(tt.expression, WithRandSource(rand.NewSource(seed)))
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				values := cr.field(tt.fieldType).values()
+				if len(values) != 1 || values[0] < tt.min || values[0] > tt.max {
+					t.Fatalf("Expected one value in range %d-%d Actual: %v", tt.min, tt.max, values)
+				}
+				seen[values[0]] = true
+
+				again, err := Parse(tt.expression, WithRandSource(rand.NewSource(seed)))
+				if err != nil {
+					t.Fatalf("Unexpected error: %s", err)
+				}
+				if !cr.Equal(again) {
+					t.Errorf("Expected the same source to pick the same values: %s %s", cr, again)
+				}
+			}
+			if len(seen) < 2 {
+				t.Errorf("Expected different sources to pick different values, got %v", seen)
+			}
+		})
+	}
+}
+
+func TestRandomErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		token      string
+		reason     Reason
+	}{
+		{"0 R/5 * * * *", "R/5", ReasonInvalidValue},
+		{"0 R(5-1) * * * *", "5-1", ReasonInvalidRange},
+		{"0 R(0-10 * * * *", "R(0-10", ReasonInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := Parse(tt.expression)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected ParseError, actual: %v", err)
+			}
+			if parseErr.Token != tt.token || parseErr.Reason != tt.reason {
+				t.Errorf("Unexpected error: %#v", parseErr)
+			}
+		})
+	}
+}
+
+func TestJitter(t *testing.T) {
+	cr, err := Parse("0 0 * * * *", WithJitter(10*time.Minute), WithRandSource(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	same, err := Parse("0 0 * * * *", WithJitter(10*time.Minute), WithRandSource(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	base := parseTime("2012-07-09 15:00")
+	offsets := map[time.Duration]bool{}
+	for i := 0; i < 20; i++ {
+		actual := cr.Next(parseTime("2012-07-09 14:46"))
+		if actual.Before(base) || !actual.Before(base.Add(10*time.Minute)) {
+			t.Fatalf("Expected jittered time within 10m after %v Actual: %v", base, actual)
+		}
+		if expected := same.Next(parseTime("2012-07-09 14:46")); actual != expected {
+			t.Errorf("Expected the same source to pick the same jitter: %v %v", expected, actual)
+		}
+		offsets[actual.Sub(base)] = true
+	}
+	if len(offsets) < 2 {
+		t.Errorf("Expected jitter to vary per occurrence")
+	}
+
+	// the iterator continues from the fire times without jitter, so no minute is skipped
+	minutely, err := Parse("0 * * * * *", WithJitter(2*time.Minute), WithRandSource(rand.NewSource(42)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	start := parseTime("2012-07-09 00:00")
+	for i, actual := range minutely.Iter(start).Take(10) {
+		if base := start.Add(time.Duration(i) * time.Minute); actual.Before(base) || !actual.Before(base.Add(2*time.Minute)) {
+			t.Errorf("Expected jittered time within 2m after %v Actual: %v", base, actual)
+		}
+	}
+
+	years, err := Parse("0 0 0 1 1 * 2010", WithJitter(time.Minute))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if actual := years.Next(parseTime("2012-07-09 14:46")); !actual.IsZero() {
+		t.Errorf("Expected zero time Actual: %v", actual)
+	}
+}
@@ -0,0 +1,100 @@
+package cron
+
+import "time"
+
+// Schedule tells the first fire time at or after from, the zero time means it never fires again.
+// CronExpression implements it, Union, Intersect and Except combine schedules.
+type Schedule interface {
+	Next(from time.Time) time.Time
+}
+
+// maxSteps bounds the search of Intersect and Except for schedules that never coincide.
+const maxSteps = 1 << 16
+
+type union []Schedule
+
+// Union fires whenever any of the schedules fires.
+func Union(first Schedule, rest ...Schedule) Schedule {
+	return append(union{first}, rest...)
+}
+
+func (u union) Next(from time.Time) time.Time {
+	var next time.Time
+	for _, schedule := range u {
+		if t := schedule.Next(from); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return next
+}
+
+type intersection []Schedule
+
+// Intersect fires when all of the schedules fire at the same instant.
+func Intersect(first Schedule, rest ...Schedule) Schedule {
+	return append(intersection{first}, rest...)
+}
+
+// Next leapfrogs the schedules to the latest of their next fire times until they agree.
+func (in intersection) Next(from time.Time) time.Time {
+	// schedules without a location of their own are evaluated in the location of from
+	location := from.Location()
+	for i := 0; i < maxSteps; i++ {
+		agree := true
+		latest := from
+		for _, schedule := range in {
+			t := schedule.Next(from)
+			if t.IsZero() {
+				return t
+			}
+			if !t.Equal(from) {
+				agree = false
+			}
+			if t.After(latest) {
+				latest = t
+			}
+		}
+		if agree {
+			return from
+		}
+		from = latest.In(location)
+	}
+	return time.Time{}
+}
+
+type exception struct {
+	base      Schedule
+	exclusion Schedule
+}
+
+// Except fires when base fires, unless exclusion fires at the same instant. To exclude whole periods the exclusion
+// must fire on every second of them, e.g. "* * * 25 12 *" excludes Christmas.
+func Except(base Schedule, exclusion Schedule) Schedule {
+	return exception{base: base, exclusion: exclusion}
+}
+
+func (e exception) Next(from time.Time) time.Time {
+	location := from.Location()
+	next := e.base.Next(from)
+	for i := 0; i < maxSteps && !next.IsZero(); i++ {
+		if !e.exclusion.Next(next.In(location)).Equal(next) {
+			return next
+		}
+		next = after(e.base, next.In(location))
+	}
+	return time.Time{}
+}
+
+// after returns the next fire time of the schedule after t. Expressions step like their iterators, by their interval
+// or to their next whole second or millisecond, other schedules by a nanosecond.
+func after(s Schedule, t time.Time) time.Time {
+	cr, ok := s.(*CronExpression)
+	switch {
+	case !ok:
+		return s.Next(t.Add(time.Nanosecond))
+	case cr.interval > 0:
+		return cr.Next(t)
+	}
+	precision := cr.precision()
+	return cr.Next(t.Truncate(precision).Add(precision))
+}
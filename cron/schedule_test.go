@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSchedules(t *testing.T) {
+	mustParse := func(expression string) *CronExpression {
+		cr, err := Parse(expression)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return cr
+	}
+	tests := []struct {
+		now      string
+		schedule Schedule
+		expected string
+	}{
+		{"2012-07-09 14:46", Union(mustParse("0 0 * * * *"), mustParse("0 50 * * * *")), "2012-07-09 14:50"},
+		{"2012-07-09 14:51", Union(mustParse("0 0 * * * *"), mustParse("0 50 * * * *")), "2012-07-09 15:00"},
+		{"2012-07-09 14:51", Union(mustParse("0 0 0 1 1 * 2012"), mustParse("0 0 16 * * *")), "2012-07-09 16:00"},
+		{"2012-07-09 14:51", Union(mustParse("0 0 0 1 1 * 2012")), ""},
+
+		{"2012-07-09 14:46", Intersect(mustParse("0 */15 * * * *"), mustParse("0 */20 * * * *")), "2012-07-09 15:00"},
+		{"2012-07-09 14:46", Intersect(mustParse("0 0 9 * * MON"), mustParse("0 0 9 13 * *")), "2012-08-13 09:00"},
+		{"2012-07-09 14:46", Intersect(mustParse("0 0 9 * * *"), mustParse("0 0 10 * * *")), ""},
+
+		{"2012-07-09 14:46", Except(mustParse("0 0 9 * * *"), mustParse("* * * * * SAT,SUN")), "2012-07-10 09:00"},
+		{"2012-07-13 14:46", Except(mustParse("0 0 9 * * *"), mustParse("* * * * * SAT,SUN")), "2012-07-16 09:00"},
+		{"2012-07-09 14:46", Except(mustParse("0 0 * * * *"), mustParse("* * 15-17 * * *")), "2012-07-09 18:00"},
+		{"2012-07-09 14:46", Except(mustParse("0 0 9 * * *"), mustParse("* * * * * *")), ""},
+		{"2012-07-09 00:00", Except(mustParse("@every 1h"), mustParse("0 0 1 * * *")), "2012-07-09 02:00"},
+		{"2012-07-09 00:00", Except(mustParse("@every 1h"), mustParse("0 0 * * * *")), ""},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			actual := tt.schedule.Next(parseTime(tt.now))
+			expected := parseTime(tt.expected)
+			if actual != expected {
+				t.Errorf("Expected: %v Actual: %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestSchedulesInLocation(t *testing.T) {
+	berlin, err := Parse("CRON_TZ=Europe/Berlin 0 0 9 * * *")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	utc, err := Parse("0 0 7 * * *")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := parseTime("2012-07-10 07:00")
+	if actual := Intersect(berlin, utc).Next(parseTime("2012-07-09 08:00")); !actual.Equal(expected) {
+		t.Errorf("Expected: %v Actual: %v", expected, actual)
+	}
+	// 09:00 in Berlin is 07:00 UTC until the clocks go back
+	expected = parseTime("2012-10-28 08:00")
+	if actual := Except(berlin, utc).Next(parseTime("2012-07-09 08:00")); !actual.Equal(expected) {
+		t.Errorf("Expected: %v Actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,357 @@
+package cron
+
+import (
+	mathbits "math/bits"
+	"sort"
+	"time"
+)
+
+// Stats describes the fire times of an expression within a window.
+type Stats struct {
+	Count       int
+	First, Last time.Time
+	// Min, Max and Mean are the gaps between consecutive fire times, zero with less than two fire times.
+	Min, Max, Mean time.Duration
+}
+
+// CountBetween returns the number of fire times at or after start and before end. The times of day are counted from
+// the field bitmasks and the days one by one, only expressions with calendars are enumerated. Jitter doesn't change
+// how many times an expression fires, so the fire times without it are counted.
+func (cr *CronExpression) CountBetween(start, end time.Time) int {
+	if !start.Before(end) || cr.isZero() {
+		return 0
+	}
+	if cr.calendar != nil {
+		return len(cr.enumerate(start, end))
+	}
+	if cr.interval > 0 {
+		// @every fires at start shifted by multiples of the interval
+		return int((end.Sub(start) - 1) / cr.interval)
+	}
+	count := 0
+	for _, s := range cr.segments(start, end) {
+		count += cr.countWall(s.wallStart, s.wallEnd)
+		if s.end.Equal(end) {
+			break
+		}
+		before, after := offsets(s.end)
+		shift := time.Duration(after-before) * time.Second
+		if shift > 0 && cr.gapPolicy == GapRunAtTransition {
+			// the wall clock times skipped by the gap fire once at the transition, unless it fires there anyway
+			if cr.countWall(s.wallEnd, s.wallEnd.Add(shift)) > 0 && cr.countWall(s.wallEnd.Add(shift), s.wallEnd.Add(shift+1)) == 0 {
+				count++
+			}
+		}
+		if shift < 0 && cr.overlapPolicy == OverlapRunOnce {
+			// the repeated wall clock times only fire the first time
+			repeated := wallClock(s.end)
+			until := s.end.Add(-shift)
+			if until.After(end) {
+				until = end
+			}
+			count -= cr.countWall(repeated, repeated.Add(until.Sub(s.end)))
+		}
+	}
+	return count
+}
+
+// Stats returns the number of fire times at or after start and before end and the gaps between them, without jitter.
+// Like CountBetween it works on the field bitmasks, only the fire times around time zone transitions are enumerated.
+func (cr *CronExpression) Stats(start, end time.Time) Stats {
+	var stats Stats
+	if stats.Count = cr.CountBetween(start, end); stats.Count == 0 {
+		return stats
+	}
+	switch {
+	case cr.calendar != nil:
+		for _, t := range cr.enumerate(start, end) {
+			stats.add(t)
+		}
+	case cr.interval > 0:
+		stats.First, stats.Last = start.Add(cr.interval), start.Add(time.Duration(stats.Count)*cr.interval)
+		stats.addGap(cr.interval)
+	default:
+		segments := cr.segments(start, end)
+		t := segments[0].start
+		for i, s := range segments {
+			interiorEnd := s.end
+			if i < len(segments)-1 {
+				// around a transition wall clock times are skipped or repeated, so the fire times are enumerated
+				if interiorEnd = s.end.Add(-margin(s.end)); interiorEnd.Before(t) {
+					interiorEnd = t
+				}
+			}
+			if t.Before(interiorEnd) {
+				cr.wallStats(s, s.wallStart.Add(t.Sub(s.start)), s.wallStart.Add(interiorEnd.Sub(s.start)), &stats)
+			}
+			if i < len(segments)-1 {
+				marginEnd := s.end.Add(margin(s.end))
+				if marginEnd.After(segments[i+1].end) {
+					marginEnd = segments[i+1].end
+				}
+				for _, fire := range cr.enumerate(interiorEnd, marginEnd) {
+					stats.add(fire)
+				}
+				t = marginEnd
+			}
+		}
+	}
+	if stats.Count > 1 {
+		stats.Mean = stats.Last.Sub(stats.First) / time.Duration(stats.Count-1)
+	}
+	return stats
+}
+
+// add records the next fire time in chronological order.
+func (s *Stats) add(t time.Time) {
+	if s.Last.IsZero() {
+		s.First = t
+	} else {
+		s.addGap(t.Sub(s.Last))
+	}
+	s.Last = t
+}
+
+func (s *Stats) addGap(gap time.Duration) {
+	if s.Min == 0 || gap < s.Min {
+		s.Min = gap
+	}
+	if gap > s.Max {
+		s.Max = gap
+	}
+}
+
+// margin returns the shift of the wall clock at a transition.
+func margin(transition time.Time) time.Duration {
+	before, after := offsets(transition)
+	if after < before {
+		return time.Duration(before-after) * time.Second
+	}
+	return time.Duration(after-before) * time.Second
+}
+
+// enumerate returns the fire times without jitter at or after start and before end one by one.
+func (cr *CronExpression) enumerate(start, end time.Time) []time.Time {
+	var times []time.Time
+	for t := cr.next(start); !t.IsZero() && t.Before(end); t = cr.after(t) {
+		times = append(times, t)
+	}
+	return times
+}
+
+// segment is a part of a window without time zone transitions, so wall clock and elapsed time agree.
+type segment struct {
+	start, end         time.Time
+	wallStart, wallEnd time.Time
+}
+
+func (cr *CronExpression) segments(start, end time.Time) []segment {
+	location := cr.location
+	if location == nil {
+		location = start.Location()
+	}
+	var segments []segment
+	for t := start.In(location); t.Before(end); {
+		s := segment{start: t, end: end, wallStart: wallClock(t)}
+		if _, zoneEnd := t.ZoneBounds(); !zoneEnd.IsZero() && zoneEnd.Before(end) {
+			s.end = zoneEnd
+		}
+		s.wallEnd = s.wallStart.Add(s.end.Sub(s.start))
+		segments = append(segments, s)
+		t = s.end
+	}
+	return segments
+}
+
+// countWall counts the wall clock fire times at or after start and before end, both in UTC.
+func (cr *CronExpression) countWall(start, end time.Time) int {
+	count := 0
+	for day := midnight(start); day.Before(end); {
+		next, ok := cr.nextDay(day)
+		if !ok {
+			day = next
+			continue
+		}
+		count += cr.firesBefore(clip(end, day)) - cr.firesBefore(clip(start, day))
+		day = next
+	}
+	return count
+}
+
+// wallStats records the fire times with a wall clock time at or after start and before end, both in UTC and within
+// the segment.
+func (cr *CronExpression) wallStats(s segment, start, end time.Time, stats *Stats) {
+	instant := func(wall time.Time) time.Time {
+		return s.start.Add(wall.Sub(s.wallStart))
+	}
+	// a day is the single unit of the top level, so a whole day is recorded at once
+	levels := append([]gapLevel{{[]int{0}, 24 * time.Hour}}, cr.gapLevels()...)
+	for day := midnight(start); day.Before(end); {
+		next, ok := cr.nextDay(day)
+		if ok {
+			levelStats(levels, 0, clip(start, day), clip(end, day), func(first, last, min, max time.Duration) {
+				stats.add(instant(day.Add(first)))
+				if last > first {
+					stats.addGap(min)
+					stats.addGap(max)
+					stats.Last = instant(day.Add(last))
+				}
+			})
+		}
+		day = next
+	}
+}
+
+// gapLevel is the values of a time of day field in units of the field.
+type gapLevel struct {
+	values []int
+	unit   time.Duration
+}
+
+func (cr *CronExpression) gapLevels() []gapLevel {
+	levels := []gapLevel{
+		{cr.field(Hour).values(), time.Hour},
+		{cr.field(Minute).values(), time.Minute},
+		{cr.field(Second).values(), time.Second},
+		{[]int{0}, time.Millisecond},
+	}
+	if millis := cr.field(Millisecond); millis != nil {
+		levels[3].values = millis.values()
+	}
+	return levels
+}
+
+// levelStats records the fire times of a matching day at or after the time of day lo and before hi. A unit of the
+// first level with all its fire times within [lo, hi) is recorded at once with the gaps of the lower levels, a unit
+// cut by lo or hi is split into the units of the next level.
+func levelStats(levels []gapLevel, base, lo, hi time.Duration, record func(first, last, min, max time.Duration)) {
+	lower := levels[1:]
+	var firstOffset, lastOffset time.Duration
+	for _, level := range lower {
+		firstOffset += time.Duration(level.values[0]) * level.unit
+		lastOffset += time.Duration(level.values[len(level.values)-1]) * level.unit
+	}
+	min, max := levelGaps(lower)
+	for _, value := range levels[0].values {
+		unitStart := base + time.Duration(value)*levels[0].unit
+		first, last := unitStart+firstOffset, unitStart+lastOffset
+		switch {
+		case first >= hi:
+			return
+		case last < lo:
+		case lo <= first && last < hi:
+			record(first, last, min, max)
+		default:
+			levelStats(lower, unitStart, lo, hi, record)
+		}
+	}
+}
+
+// levelGaps returns the shortest and longest gap between consecutive fire times within a unit above the levels. Each
+// level contributes the gaps from its values with the lower levels at their last and then at their first value.
+func levelGaps(levels []gapLevel) (min, max time.Duration) {
+	for i, level := range levels {
+		if len(level.values) < 2 {
+			continue
+		}
+		// spread of the lower levels, from their first to their last value
+		var spread time.Duration
+		for _, lower := range levels[i+1:] {
+			spread += time.Duration(lower.values[len(lower.values)-1]-lower.values[0]) * lower.unit
+		}
+		for j := 1; j < len(level.values); j++ {
+			gap := time.Duration(level.values[j]-level.values[j-1])*level.unit - spread
+			if min == 0 || gap < min {
+				min = gap
+			}
+			if gap > max {
+				max = gap
+			}
+		}
+	}
+	return min, max
+}
+
+// nextDay returns the day after the given wall clock midnight, or the first day of the next month if the month
+// doesn't match. ok reports whether the given day matches the day, month and year fields.
+func (cr *CronExpression) nextDay(day time.Time) (next time.Time, ok bool) {
+	y, m, d := day.Date()
+	if !cr.field(Month).contains(int(m)) || !cr.field(Year).contains(y) {
+		return time.Date(y, m+1, 1, 0, 0, 0, 0, time.UTC), false
+	}
+	return time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC), cr.matchesDays(day)
+}
+
+// firesBefore counts the fire times of a matching day before the given time of day.
+func (cr *CronExpression) firesBefore(tod time.Duration) int {
+	precision := cr.precision()
+	// a fire time is before tod if it is before tod rounded up to the precision
+	tod = (tod + precision - 1) / precision * precision
+	hours, minutes, seconds := cr.field(Hour), cr.field(Minute), cr.field(Second)
+	millis := cr.field(Millisecond)
+	if millis == nil {
+		millis = orZeroMillis(nil)
+	}
+	perMinute := seconds.count() * millis.count()
+	perHour := minutes.count() * perMinute
+	if tod >= 24*time.Hour {
+		return hours.count() * perHour
+	}
+	h, m := int(tod/time.Hour), int(tod%time.Hour/time.Minute)
+	s, ms := int(tod%time.Minute/time.Second), int(tod%time.Second/time.Millisecond)
+	count := hours.countBelow(h) * perHour
+	if hours.contains(h) {
+		count += minutes.countBelow(m) * perMinute
+		if minutes.contains(m) {
+			count += seconds.countBelow(s) * millis.count()
+			if seconds.contains(s) {
+				count += millis.countBelow(ms)
+			}
+		}
+	}
+	return count
+}
+
+// count returns the number of values of the field.
+func (cr *CronField) count() int {
+	if cr.isListField() {
+		return len(cr.list)
+	}
+	return mathbits.OnesCount64(uint64(cr.bits))
+}
+
+// countBelow returns the number of values of the field below the given one.
+func (cr *CronField) countBelow(value int) int {
+	if cr.isListField() {
+		return sort.SearchInts(cr.list, value)
+	}
+	return mathbits.OnesCount64(uint64(cr.bits) & (1<<value - 1))
+}
+
+// contains reports whether the value is set, a missing field contains every value.
+func (cr *CronField) contains(value int) bool {
+	if cr == nil {
+		return true
+	}
+	if cr.isListField() {
+		return cr.nextInList(value) == value
+	}
+	return cr.GetBit(value) == 1
+}
+
+func midnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// clip returns the time of day of t within the day, 0 before the day and 24 hours after it.
+func clip(t time.Time, day time.Time) time.Duration {
+	tod := t.Sub(day)
+	if tod < 0 {
+		return 0
+	}
+	if tod > 24*time.Hour {
+		return 24 * time.Hour
+	}
+	return tod
+}
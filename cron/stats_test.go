@@ -0,0 +1,106 @@
+package cron
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	tests := []struct {
+		start, end string
+		expression string
+		expected   Stats
+	}{
+		{"2012-07-09 00:00", "2012-07-16 00:00", "0 0 9 * * MON-FRI",
+			Stats{Count: 5, First: parseTime("2012-07-09 09:00"), Last: parseTime("2012-07-13 09:00"), Min: 24 * time.Hour, Max: 24 * time.Hour, Mean: 24 * time.Hour}},
+		{"2012-07-09 10:00", "2012-07-17 00:00", "0 0 9 * * MON-FRI",
+			Stats{Count: 5, First: parseTime("2012-07-10 09:00"), Last: parseTime("2012-07-16 09:00"), Min: 24 * time.Hour, Max: 72 * time.Hour, Mean: 36 * time.Hour}},
+		{"2012-07-09 14:00", "2012-07-09 14:00", "0 * * * * *", Stats{}},
+		{"2012-07-09 12:00:00.5", "2012-07-09 13:00:00.5", "0 */7 * * * *",
+			Stats{Count: 9, First: parseTime("2012-07-09 12:07"), Last: parseTime("2012-07-09 13:00"), Min: 4 * time.Minute, Max: 7 * time.Minute, Mean: 53 * time.Minute / 8}},
+		{"2012-07-09 12:00:00.5", "2012-07-09 13:00:00.5", "0 0 * * * *",
+			Stats{Count: 1, First: parseTime("2012-07-09 13:00"), Last: parseTime("2012-07-09 13:00")}},
+		{"2012-07-09 14:00", "2012-07-09 15:00", "@every 25m",
+			Stats{Count: 2, First: parseTime("2012-07-09 14:25"), Last: parseTime("2012-07-09 14:50"), Min: 25 * time.Minute, Max: 25 * time.Minute, Mean: 25 * time.Minute}},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := cr.Stats(parseTime(tt.start), parseTime(tt.end)); actual != tt.expected {
+				t.Errorf("Expected: %+v Actual: %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestStatsEnumerated(t *testing.T) {
+	tests := []struct {
+		start, end string
+		expression string
+		opts       []Option
+	}{
+		{"2012-07-09 14:17:03", "2012-07-12 09:00", "0 0 * * * *", nil},
+		{"2012-07-09 12:00:00.5", "2012-07-09 13:00:00.5", "0 */7 * * * *", nil},
+		{"2012-07-09 14:17:03.0004", "2012-07-09 14:19:00", "0,250,400 * * * * * *", []Option{WithMilliseconds()}},
+		{"2012-07-09 14:17:03", "2012-09-12 09:00", "0 15,45 9-17 * * MON-FRI", nil},
+		{"2012-01-01 00:00", "2014-01-01 00:00", "0 0 12 L * *", nil},
+		{"2012-01-01 00:00", "2016-01-01 00:00", "0 0 12 LW 2,3 * 2013-2014", nil},
+		{"2012-01-01 00:00", "2013-01-01 00:00", "0 0 12 1 * MON", []Option{WithDayPolicy(DayVixie)}},
+		{"2012-07-09 14:17:03.120", "2012-07-09 14:19:00", "0,250,400 */7 * * * * *", []Option{WithMilliseconds()}},
+		{"2012-07-09 14:17:03", "2012-07-10 14:00", "*/10 * 6-8 * * *", nil},
+		{"2023-03-24 00:00", "2023-04-02 00:00", "CRON_TZ=Europe/Berlin 0 */20 * * * *", nil},
+		{"2023-03-24 00:00", "2023-04-02 00:00", "CRON_TZ=Europe/Berlin 0 30 2 * * *", nil},
+		{"2023-03-24 00:00", "2023-04-02 00:00", "CRON_TZ=Europe/Berlin 0 30 2 * * *", []Option{WithGapPolicy(GapSkip)}},
+		{"2023-10-27 00:00", "2023-11-02 00:00", "CRON_TZ=Europe/Berlin 0 */20 * * * *", nil},
+		{"2023-10-27 00:00", "2023-11-02 00:00", "CRON_TZ=Europe/Berlin 0 */20 * * * *", []Option{WithOverlapPolicy(OverlapRunTwice)}},
+		{"2023-10-29 00:40", "2023-10-29 01:10", "CRON_TZ=Europe/Berlin 0 */20 * * * *", nil},
+		{"2023-01-01 00:00", "2024-01-01 00:00", "CRON_TZ=Europe/Berlin 0 0 1-3 * * SUN", nil},
+		{"2012-07-09 00:00", "2012-07-09 00:30", "* * 0,23 * * *", nil},
+		{"2012-07-09 22:59:30", "2012-07-10 00:00:30", "* * 0,23 * * *", nil},
+		{"2012-07-09 14:17:03.0004", "2012-07-09 16:05:00", "*/3 0-10,30 * * * *", nil},
+		{"2012-07-09 14:17:03.0004", "2012-07-09 14:21:00.5", "0,250,400 * 17-20 * * * *", []Option{WithMilliseconds()}},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("#%d", i), func(t *testing.T) {
+			cr, err := Parse(tt.expression, tt.opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			start, end := parseTime(tt.start), parseTime(tt.end)
+			expected := Stats{}
+			for _, fire := range cr.enumerate(start, end) {
+				expected.Count++
+				expected.add(fire)
+			}
+			if expected.Count > 1 {
+				expected.Mean = expected.Last.Sub(expected.First) / time.Duration(expected.Count-1)
+			}
+			if actual := cr.CountBetween(start, end); actual != expected.Count {
+				t.Errorf("Expected: %v Actual: %v", expected.Count, actual)
+			}
+			actual := cr.Stats(start, end)
+			if actual.Count != expected.Count || !actual.First.Equal(expected.First) || !actual.Last.Equal(expected.Last) ||
+				actual.Min != expected.Min || actual.Max != expected.Max || actual.Mean != expected.Mean {
+				t.Errorf("Expected: %+v Actual: %+v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestCountBetweenJitter(t *testing.T) {
+	cr, err := Parse("0 * * * * *", WithJitter(2*time.Minute))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if expected, actual := 60, cr.CountBetween(parseTime("2012-07-09 12:00"), parseTime("2012-07-09 13:00")); actual != expected {
+		t.Errorf("Expected: %v Actual: %v", expected, actual)
+	}
+	expected := Stats{Count: 60, First: parseTime("2012-07-09 12:00"), Last: parseTime("2012-07-09 12:59"), Min: time.Minute, Max: time.Minute, Mean: time.Minute}
+	if actual := cr.Stats(parseTime("2012-07-09 12:00"), parseTime("2012-07-09 13:00")); actual != expected {
+		t.Errorf("Expected: %+v Actual: %+v", expected, actual)
+	}
+}
@@ -0,0 +1,139 @@
+package cron
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	monthNames   = []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	weekdayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+// String returns the canonical form of the expression: six fields (seven with years, and a leading millisecond field
+// with WithMilliseconds) where consecutive values are collapsed into ranges and steps, and months and days of week
+// are named. Macros are expanded. The zero CronExpression returns an empty string.
+func (cr *CronExpression) String() string {
+	if cr.isZero() {
+		return ""
+	}
+	if cr.interval > 0 {
+		return fmt.Sprintf("%s %s", everyMacro, cr.interval)
+	}
+	var fields []string
+	if millis := cr.field(Millisecond); millis != nil {
+		fields = append(fields, millis.String())
+	}
+	for _, fieldType := range []CronFieldType{Second, Minute, Hour, DOM, Month, DOW} {
+		fields = append(fields, cr.field(fieldType).String())
+	}
+	if years := cr.field(Year); years != nil {
+		fields = append(fields, years.String())
+	}
+	expression := strings.Join(fields, " ")
+	if cr.location != nil {
+		expression = fmt.Sprintf("CRON_TZ=%s %s", cr.location, expression)
+	}
+	return expression
+}
+
+// Equal reports whether both expressions fire at the same times, no matter how they were written.
+func (cr *CronExpression) Equal(other *CronExpression) bool {
+	return cr.String() == other.String() && cr.gapPolicy == other.gapPolicy && cr.overlapPolicy == other.overlapPolicy &&
+		cr.dayPolicy == other.dayPolicy && cr.jitter == other.jitter && cr.calendar == other.calendar
+}
+
+// String returns the canonical form of the field.
+func (cr *CronField) String() string {
+	var items []string
+	if cr.isFull() {
+		items = append(items, "*")
+	} else if values := cr.values(); len(values) > 0 {
+		items = append(items, cr.format(values)...)
+	}
+	var rules []string
+	for _, rule := range cr.rules {
+		rules = append(rules, rule.String())
+	}
+	sort.Strings(rules)
+	for i, rule := range rules {
+		if i == 0 || rule != rules[i-1] {
+			items = append(items, rule)
+		}
+	}
+	return strings.Join(items, ",")
+}
+
+// format collapses the values into a step, or into ranges of three or more consecutive values.
+func (cr *CronField) format(values []int) []string {
+	if len(values) >= 3 && isStep(values, cr.fieldRange) {
+		return []string{fmt.Sprintf("*/%d", values[1]-values[0])}
+	}
+	if step, ok := arithmetic(values); ok && len(values) >= 3 {
+		start, end := values[0], values[len(values)-1]
+		if end+step > cr.fieldRange.max {
+			return []string{fmt.Sprintf("%s/%d", cr.name(start), step)}
+		}
+		return []string{fmt.Sprintf("%s-%s/%d", cr.name(start), cr.name(end), step)}
+	}
+	var items []string
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			items = append(items, cr.name(values[i])+"-"+cr.name(values[j]))
+			i = j + 1
+			continue
+		}
+		items = append(items, cr.name(values[i]))
+		i++
+	}
+	return items
+}
+
+// arithmetic returns the step if the values are evenly spaced by more than one.
+func arithmetic(values []int) (int, bool) {
+	if len(values) < 2 {
+		return 0, false
+	}
+	step := values[1] - values[0]
+	for i := 2; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return 0, false
+		}
+	}
+	return step, step > 1
+}
+
+func (cr *CronField) name(value int) string {
+	switch cr.fieldType {
+	case Month:
+		return monthNames[value-1]
+	case DOW:
+		return weekdayNames[value%7]
+	}
+	return strconv.Itoa(value)
+}
+
+func (r dayRule) String() string {
+	switch r.kind {
+	case lastDayOfMonth:
+		if r.day == 0 {
+			return "L"
+		}
+		return fmt.Sprintf("L-%d", r.day)
+	case lastWeekdayOfMonth:
+		return "LW"
+	case nearestWeekday:
+		return fmt.Sprintf("%dW", r.day)
+	case lastDayOfWeekInMonth:
+		return weekdayNames[r.weekday] + "L"
+	case nthDayOfWeekInMonth:
+		return fmt.Sprintf("%s#%d", weekdayNames[r.weekday], r.nth)
+	}
+	return ""
+}
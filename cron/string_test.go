@@ -0,0 +1,114 @@
+package cron
+
+import (
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"* * * * * *", "* * * * * *"},
+		{"0 0/15 * * * *", "0 */15 * * * *"},
+		{"0 0,15,30,45 * * * *", "0 */15 * * * *"},
+		{"0 5/15 * * * *", "0 5/15 * * * *"},
+		{"0 10-40/10 * * * *", "0 10-40/10 * * * *"},
+		{"0 1,2,3,4,10 * * * *", "0 1-4,10 * * * *"},
+		{"0 1,2 9-17 * * *", "0 1,2 9-17 * * *"},
+		{"0 0 0,23 * * *", "0 0 0,23 * * *"},
+		{"0 0,30 * * * *", "0 0,30 * * * *"},
+		{"0 0 0 * * SAT,SUN", "0 0 0 * * SUN,SAT"},
+		{"0 30 9 ? 1,3 mon-fri", "0 30 9 * JAN,MAR MON-FRI"},
+		{"0 0 0 * * 7", "0 0 0 * * SUN"},
+		{"0 0 0 * * 0-7", "0 0 0 * * *"},
+		{"0 0 0 * * MON-SUN", "0 0 0 * * *"},
+		{"0 0 0 * * FRI-SUN", "0 0 0 * * SUN,FRI,SAT"},
+		{"0 0 0 * * L", "0 0 0 * * SAT"},
+		{"0 0 12 L,1,15 * *", "0 0 12 1,15,L * *"},
+		{"0 0 12 L-2,LW,15W,L * *", "0 0 12 15W,L,L-2,LW * *"},
+		{"0 0 9 * * 1#2,5L", "0 0 9 * * FRIL,MON#2"},
+		{"*/15 9 * * 1-5", "0 */15 9 * * MON-FRI"},
+		{"0 0 0 1 1 * 2020-2030/5", "0 0 0 1 JAN * 2020-2030/5"},
+		{"0 0 0 1 1 * 2089/5", "0 0 0 1 JAN * 2089/5"},
+		{"0 0 0 1 1 * *", "0 0 0 1 JAN *"},
+		{"@daily", "0 0 0 * * *"},
+		{"@every 90s", "@every 1m30s"},
+		{"CRON_TZ=Europe/Berlin 0 0 9 * * *", "CRON_TZ=Europe/Berlin 0 0 9 * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			cr, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			actual := cr.String()
+			if actual != tt.expected {
+				t.Errorf("Expected: %q Actual: %q", tt.expected, actual)
+			}
+			roundTrip, err := Parse(actual)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !roundTrip.Equal(cr) {
+				t.Errorf("Expected %q to equal %q", actual, tt.expression)
+			}
+		})
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		first    string
+		second   string
+		expected bool
+	}{
+		{"0 0 0 * * *", "@daily", true},
+		{"0 */20 * * * *", "0 0,20,40 * * * *", true},
+		{"0 0 0 * * 0", "0 0 0 * * Sun", true},
+		{"0 0 9 * * *", "0 9 * * *", true},
+		{"0 0 0 1 1 * *", "@yearly", true},
+		{"0 0 9 * * *", "0 0 10 * * *", false},
+		{"0 0 9 * * *", "CRON_TZ=UTC 0 0 9 * * *", false},
+		{"@every 1h", "@every 60m", true},
+		{"@every 1h", "0 0 * * * *", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.first+" "+tt.second, func(t *testing.T) {
+			first, err := Parse(tt.first)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			second, err := Parse(tt.second)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := first.Equal(second); actual != tt.expected {
+				t.Errorf("Expected: %v Actual: %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestStringMilliseconds(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"250,750 * * * * * *", "250,750 * * * * * *"},
+		{"0,100,200,300,400,500,600,700,800,900 0 * * * * *", "*/100 0 * * * * *"},
+		{"* 0 0 * * * *", "* 0 0 * * * *"},
+		{"@daily", "0 0 0 0 * * *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			cr, err := Parse(tt.expression, WithMilliseconds())
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if actual := cr.String(); actual != tt.expected {
+				t.Errorf("Expected: %q Actual: %q", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/vestverg/baymax/collections/queue"
@@ -129,6 +130,9 @@ func NewCronJob(run Run, cronExpression string) (*CronJob, error) {
 	if err != nil {
 		return nil, fmt.Errorf("can't create CronJob: %w", err)
 	}
+	if expression.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("can't create CronJob: %q never fires again", cronExpression)
+	}
 	return &CronJob{
 		run:        run,
 		expression: expression,
@@ -145,11 +149,16 @@ func (cr *CronJob) Run(ctx context.Context) error {
 func (cr *CronJob) GetNextExecution() time.Time {
 	now := time.Now()
 	if cr.lastCompletion == nil {
-		cr.expression.Next(now)
+		return cr.expression.Next(now)
 	}
 	return cr.expression.Next(*cr.lastCompletion)
 }
 
+// GetDelay returns math.MaxInt64 once the expression has no next execution, e.g. its years ran out.
 func (cr *CronJob) GetDelay() int64 {
-	return cr.GetNextExecution().UnixNano() - time.Now().UnixNano()
+	next := cr.GetNextExecution()
+	if next.IsZero() {
+		return math.MaxInt64
+	}
+	return next.UnixNano() - time.Now().UnixNano()
 }
@@ -118,5 +118,9 @@ func (s *scheduledExecutorService) runJob(ctx context.Context, job Job) {
 		})
 		return
 	}
+	if job.GetNextExecution().IsZero() {
+		// the job won't run again
+		return
+	}
 	s.queue.Offer(job)
 }
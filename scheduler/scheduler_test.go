@@ -3,10 +3,13 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"math"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/vestverg/baymax/cron"
 )
 
 func TestNewScheduledExecutorService(t *testing.T) {
@@ -100,6 +103,38 @@ func TestWithCronJob(t *testing.T) {
 	}
 }
 
+func TestNewCronJobWithMacro(t *testing.T) {
+	job, err := NewCronJob(func(ctx context.Context) error {
+		return nil
+	}, "@every 1h")
+	if err != nil {
+		t.Fatalf("failed to create job: %v", err)
+	}
+	if delay := time.Duration(job.GetDelay()); delay <= 59*time.Minute || delay > time.Hour {
+		t.Fatalf("unexpected delay %v", delay)
+	}
+}
+
+func TestNewCronJobWithoutNextExecution(t *testing.T) {
+	_, err := NewCronJob(func(ctx context.Context) error {
+		return nil
+	}, "0 0 0 1 1 * 2020")
+	if err == nil {
+		t.Fatalf("expected error for an expression that never fires again")
+	}
+}
+
+func TestCronJobDelayWithoutNextExecution(t *testing.T) {
+	expression, err := cron.Parse("0 0 0 1 1 * 2020")
+	if err != nil {
+		t.Fatalf("failed to parse expression: %v", err)
+	}
+	job := &CronJob{expression: expression}
+	if delay := job.GetDelay(); delay != math.MaxInt64 {
+		t.Fatalf("unexpected delay %v", time.Duration(delay))
+	}
+}
+
 func TestShutDown(t *testing.T) {
 	ctx := context.Background()
 	scheduler := NewScheduledExecutorService(ctx)